@@ -0,0 +1,406 @@
+// Package derived computes metrics derived from the body measures returned by
+// the Nokia Health API such as BMI, fat free mass index and blood pressure
+// classification.
+//
+// Readings are paired using the measure group they were taken in. Height is
+// rarely measured in the same group as weight so the most recent height taken
+// at or before the group is used, falling back to the earliest height known.
+package derived
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/category"
+)
+
+// BMICategory is the WHO classification of a body mass index.
+type BMICategory int
+
+// BMICategory constants.
+const (
+	Underweight BMICategory = iota
+	NormalWeight
+	Overweight
+	Obese
+)
+
+// String returns the name of the category.
+func (c BMICategory) String() string {
+	switch c {
+	case Underweight:
+		return "Underweight"
+	case NormalWeight:
+		return "NormalWeight"
+	case Overweight:
+		return "Overweight"
+	case Obese:
+		return "Obese"
+	}
+	return "BMICategory(" + strconv.Itoa(int(c)) + ")"
+}
+
+// ClassifyBMI returns the WHO category of the BMI provided.
+func ClassifyBMI(bmi float64) BMICategory {
+	switch {
+	case bmi < 18.5:
+		return Underweight
+	case bmi < 25:
+		return NormalWeight
+	case bmi < 30:
+		return Overweight
+	}
+	return Obese
+}
+
+// BMI is a body mass index computed from a weight and height.
+type BMI struct {
+	Date     time.Time
+	GrpID    int
+	BMI      float64
+	Category BMICategory
+}
+
+// Index is a mass index in kg/m² such as the fat free mass index.
+type Index struct {
+	Date  time.Time
+	GrpID int
+	Index float64
+}
+
+// BodyFat is a point in the body fat trend. Change is the difference in
+// percentage points with the previous point and is zero for the first one.
+type BodyFat struct {
+	Date    time.Time
+	GrpID   int
+	Percent float64
+	Kgs     float64
+	Change  float64
+}
+
+// BloodPressureCategory is the ACC/AHA 2017 classification of a blood
+// pressure reading.
+type BloodPressureCategory int
+
+// BloodPressureCategory constants.
+const (
+	NormalPressure BloodPressureCategory = iota
+	ElevatedPressure
+	HypertensionStage1
+	HypertensionStage2
+	HypertensiveCrisis
+)
+
+// String returns the name of the category.
+func (c BloodPressureCategory) String() string {
+	switch c {
+	case NormalPressure:
+		return "Normal"
+	case ElevatedPressure:
+		return "Elevated"
+	case HypertensionStage1:
+		return "HypertensionStage1"
+	case HypertensionStage2:
+		return "HypertensionStage2"
+	case HypertensiveCrisis:
+		return "HypertensiveCrisis"
+	}
+	return "BloodPressureCategory(" + strconv.Itoa(int(c)) + ")"
+}
+
+// ClassifyBloodPressure returns the category of a reading. The highest
+// category reached by either the systolic or diastolic value is used.
+func ClassifyBloodPressure(systolic float64, diastolic float64) BloodPressureCategory {
+	switch {
+	case systolic > 180 || diastolic > 120:
+		return HypertensiveCrisis
+	case systolic >= 140 || diastolic >= 90:
+		return HypertensionStage2
+	case systolic >= 130 || diastolic >= 80:
+		return HypertensionStage1
+	case systolic >= 120:
+		return ElevatedPressure
+	}
+	return NormalPressure
+}
+
+// MeanArterialPressure returns the mean arterial pressure estimated as the
+// diastolic pressure plus a third of the pulse pressure.
+func MeanArterialPressure(systolic float64, diastolic float64) float64 {
+	return diastolic + (systolic-diastolic)/3
+}
+
+// BloodPressure is a blood pressure reading built from the systolic and
+// diastolic measures of the same group. HeartPulse is set if the group also
+// contains a pulse.
+type BloodPressure struct {
+	Date                 time.Time
+	GrpID                int
+	Systolic             float64
+	Diastolic            float64
+	MeanArterialPressure float64
+	HeartPulse           *float64
+	Category             BloodPressureCategory
+}
+
+// Metrics contains every metric that could be derived from a BodyMeasures.
+// Each series is sorted by date.
+type Metrics struct {
+	BMI              []BMI
+	FatFreeMassIndex []Index
+	FatMassIndex     []Index
+	BodyFatTrend     []BodyFat
+	BloodPressure    []BloodPressure
+}
+
+// Compute derives all the metrics from the body measures provided.
+func Compute(bm *nokiahealth.BodyMeasures) Metrics {
+	return Metrics{
+		BMI:              BMISeries(bm),
+		FatFreeMassIndex: FatFreeMassIndexSeries(bm),
+		FatMassIndex:     FatMassIndexSeries(bm),
+		BodyFatTrend:     BodyFatTrend(bm),
+		BloodPressure:    BloodPressureSeries(bm),
+	}
+}
+
+// group holds the readings of one measure group.
+type group struct {
+	date        time.Time
+	grpID       int
+	weight      *float64
+	fatFreeMass *float64
+	fatMass     *float64
+	fatRatio    *float64
+	systolic    *float64
+	diastolic   *float64
+	pulse       *float64
+}
+
+// fatMassKgs returns the fat mass of the group either as measured or computed
+// from the weight and fat ratio.
+func (g group) fatMassKgs() (float64, bool) {
+	switch {
+	case g.fatMass != nil:
+		return *g.fatMass, true
+	case g.weight != nil && g.fatRatio != nil:
+		return *g.weight * *g.fatRatio / 100, true
+	case g.weight != nil && g.fatFreeMass != nil:
+		return *g.weight - *g.fatFreeMass, true
+	}
+	return 0, false
+}
+
+// fatFreeMassKgs returns the fat free mass of the group either as measured or
+// computed from the weight and fat mass.
+func (g group) fatFreeMassKgs() (float64, bool) {
+	if g.fatFreeMass != nil {
+		return *g.fatFreeMass, true
+	}
+	if fm, ok := g.fatMassKgs(); ok && g.weight != nil {
+		return *g.weight - fm, true
+	}
+	return 0, false
+}
+
+// fatPercent returns the fat ratio of the group either as measured or
+// computed from the weight and fat mass.
+func (g group) fatPercent() (float64, bool) {
+	if g.fatRatio != nil {
+		return *g.fatRatio, true
+	}
+	if fm, ok := g.fatMassKgs(); ok && g.weight != nil && *g.weight > 0 {
+		return fm / *g.weight * 100, true
+	}
+	return 0, false
+}
+
+// groups collects the readings of the body measures by measure group sorted
+// by date. Objectives are not readings so their groups are skipped.
+func groups(bm *nokiahealth.BodyMeasures) []*group {
+	byID := map[int]*group{}
+	var ordered []*group
+	get := func(id int, d time.Time) *group {
+		g, ok := byID[id]
+		if !ok {
+			g = &group{date: d, grpID: id}
+			byID[id] = g
+			ordered = append(ordered, g)
+		}
+		return g
+	}
+	val := func(v float64) *float64 {
+		return &v
+	}
+
+	for _, m := range bm.Weights {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).weight = val(m.Kgs)
+	}
+	for _, m := range bm.FatFreeMass {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).fatFreeMass = val(m.Kgs)
+	}
+	for _, m := range bm.FatMassWeights {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).fatMass = val(m.Kgs)
+	}
+	for _, m := range bm.FatRatios {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).fatRatio = val(m.Ratio)
+	}
+	for _, m := range bm.SystolicBloodPressures {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).systolic = val(m.MmHg)
+	}
+	for _, m := range bm.DiastolicBloodPressures {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).diastolic = val(m.MmHg)
+	}
+	for _, m := range bm.HeartPulses {
+		if m.Category == category.UserObjective {
+			continue
+		}
+		get(m.GrpID, m.Date).pulse = val(m.BPM)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].date.Before(ordered[j].date)
+	})
+	return ordered
+}
+
+// heightAt returns the most recent height taken at or before the date. If no
+// height was taken before the date the earliest height is used.
+func heightAt(heights []nokiahealth.Height, d time.Time) (float64, bool) {
+	var best *nokiahealth.Height
+	var earliest *nokiahealth.Height
+	for i := range heights {
+		h := &heights[i]
+		if h.Meters <= 0 {
+			continue
+		}
+		if earliest == nil || h.Date.Before(earliest.Date) {
+			earliest = h
+		}
+		if !h.Date.After(d) && (best == nil || h.Date.After(best.Date)) {
+			best = h
+		}
+	}
+	if best == nil {
+		best = earliest
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.Meters, true
+}
+
+// BMISeries computes the BMI for every measure group with a weight.
+func BMISeries(bm *nokiahealth.BodyMeasures) []BMI {
+	var series []BMI
+	for _, g := range groups(bm) {
+		if g.weight == nil {
+			continue
+		}
+		h, ok := heightAt(bm.Heights, g.date)
+		if !ok {
+			continue
+		}
+		bmi := *g.weight / (h * h)
+		series = append(series, BMI{Date: g.date, GrpID: g.grpID, BMI: bmi, Category: ClassifyBMI(bmi)})
+	}
+	return series
+}
+
+// FatFreeMassIndexSeries computes the fat free mass index (FFMI) for every
+// measure group with a fat free mass or enough readings to compute it.
+func FatFreeMassIndexSeries(bm *nokiahealth.BodyMeasures) []Index {
+	var series []Index
+	for _, g := range groups(bm) {
+		ffm, ok := g.fatFreeMassKgs()
+		if !ok {
+			continue
+		}
+		h, ok := heightAt(bm.Heights, g.date)
+		if !ok {
+			continue
+		}
+		series = append(series, Index{Date: g.date, GrpID: g.grpID, Index: ffm / (h * h)})
+	}
+	return series
+}
+
+// FatMassIndexSeries computes the fat mass index (FMI) for every measure group
+// with a fat mass or enough readings to compute it.
+func FatMassIndexSeries(bm *nokiahealth.BodyMeasures) []Index {
+	var series []Index
+	for _, g := range groups(bm) {
+		fm, ok := g.fatMassKgs()
+		if !ok {
+			continue
+		}
+		h, ok := heightAt(bm.Heights, g.date)
+		if !ok {
+			continue
+		}
+		series = append(series, Index{Date: g.date, GrpID: g.grpID, Index: fm / (h * h)})
+	}
+	return series
+}
+
+// BodyFatTrend returns the body fat percentage of every measure group where
+// it is known along with the change from the previous group.
+func BodyFatTrend(bm *nokiahealth.BodyMeasures) []BodyFat {
+	var series []BodyFat
+	for _, g := range groups(bm) {
+		p, ok := g.fatPercent()
+		if !ok {
+			continue
+		}
+		bf := BodyFat{Date: g.date, GrpID: g.grpID, Percent: p}
+		if fm, ok := g.fatMassKgs(); ok {
+			bf.Kgs = fm
+		}
+		if len(series) > 0 {
+			bf.Change = p - series[len(series)-1].Percent
+		}
+		series = append(series, bf)
+	}
+	return series
+}
+
+// BloodPressureSeries pairs the systolic and diastolic measures of each
+// measure group and classifies the reading.
+func BloodPressureSeries(bm *nokiahealth.BodyMeasures) []BloodPressure {
+	var series []BloodPressure
+	for _, g := range groups(bm) {
+		if g.systolic == nil || g.diastolic == nil {
+			continue
+		}
+		series = append(series, BloodPressure{
+			Date:                 g.date,
+			GrpID:                g.grpID,
+			Systolic:             *g.systolic,
+			Diastolic:            *g.diastolic,
+			MeanArterialPressure: MeanArterialPressure(*g.systolic, *g.diastolic),
+			HeartPulse:           g.pulse,
+			Category:             ClassifyBloodPressure(*g.systolic, *g.diastolic),
+		})
+	}
+	return series
+}
@@ -0,0 +1,91 @@
+package derived
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/category"
+)
+
+func TestCompute(t *testing.T) {
+	d1 := time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(0, 0, 7)
+
+	bm := &nokiahealth.BodyMeasures{
+		Heights:   []nokiahealth.Height{{Date: d1.AddDate(-1, 0, 0), GrpID: 1, Meters: 1.8}},
+		Weights:   []nokiahealth.Weight{{Date: d2, GrpID: 3, Kgs: 80}, {Date: d1, GrpID: 2, Kgs: 81}},
+		FatRatios: []nokiahealth.FatRatio{{Date: d1, GrpID: 2, Ratio: 20}, {Date: d2, GrpID: 3, Ratio: 19}},
+		SystolicBloodPressures: []nokiahealth.SystolicBloodPressure{
+			{Date: d1, GrpID: 4, MmHg: 132},
+		},
+		DiastolicBloodPressures: []nokiahealth.DiastolicBloodPressure{
+			{Date: d1, GrpID: 4, MmHg: 78},
+		},
+	}
+
+	m := Compute(bm)
+
+	if len(m.BMI) != 2 {
+		t.Fatalf("expected 2 bmi values got %d", len(m.BMI))
+	}
+	if m.BMI[0].GrpID != 2 || math.Abs(m.BMI[0].BMI-25) > 1e-9 || m.BMI[0].Category != Overweight {
+		t.Errorf("unexpected first bmi %+v", m.BMI[0])
+	}
+
+	if len(m.FatFreeMassIndex) != 2 || math.Abs(m.FatFreeMassIndex[1].Index-64.8/3.24) > 1e-9 {
+		t.Errorf("unexpected ffmi %+v", m.FatFreeMassIndex)
+	}
+	if len(m.FatMassIndex) != 2 || math.Abs(m.FatMassIndex[0].Index-16.2/3.24) > 1e-9 {
+		t.Errorf("unexpected fmi %+v", m.FatMassIndex)
+	}
+
+	if len(m.BodyFatTrend) != 2 || m.BodyFatTrend[0].Change != 0 || math.Abs(m.BodyFatTrend[1].Change+1) > 1e-9 {
+		t.Errorf("unexpected body fat trend %+v", m.BodyFatTrend)
+	}
+
+	if len(m.BloodPressure) != 1 {
+		t.Fatalf("expected 1 blood pressure reading got %d", len(m.BloodPressure))
+	}
+	bp := m.BloodPressure[0]
+	if bp.Category != HypertensionStage1 || bp.MeanArterialPressure != 96 {
+		t.Errorf("unexpected blood pressure %+v", bp)
+	}
+}
+
+func TestComputeSkipsObjectives(t *testing.T) {
+	d := time.Date(2018, 1, 1, 8, 0, 0, 0, time.UTC)
+	bm := &nokiahealth.BodyMeasures{
+		Heights:   []nokiahealth.Height{{Date: d, GrpID: 1, Meters: 1.8}},
+		Weights:   []nokiahealth.Weight{{Date: d, GrpID: 2, Kgs: 81}, {Date: d, GrpID: 3, Kgs: 70, Category: category.UserObjective}},
+		FatRatios: []nokiahealth.FatRatio{{Date: d, GrpID: 3, Ratio: 15, Category: category.UserObjective}},
+	}
+
+	// A weight goal is not a reading.
+	m := Compute(bm)
+	if len(m.BMI) != 1 || m.BMI[0].GrpID != 2 {
+		t.Errorf("expected only the real weight to have a bmi got %+v", m.BMI)
+	}
+	if len(m.FatFreeMassIndex) != 0 || len(m.BodyFatTrend) != 0 {
+		t.Errorf("expected no point for the objective got %+v %+v", m.FatFreeMassIndex, m.BodyFatTrend)
+	}
+}
+
+func TestClassifyBloodPressure(t *testing.T) {
+	tests := []struct {
+		sys, dia float64
+		want     BloodPressureCategory
+	}{
+		{110, 70, NormalPressure},
+		{125, 75, ElevatedPressure},
+		{118, 85, HypertensionStage1},
+		{150, 85, HypertensionStage2},
+		{185, 100, HypertensiveCrisis},
+	}
+	for _, test := range tests {
+		if got := ClassifyBloodPressure(test.sys, test.dia); got != test.want {
+			t.Errorf("%v/%v expected %s got %s", test.sys, test.dia, test.want, got)
+		}
+	}
+}
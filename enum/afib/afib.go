@@ -0,0 +1,13 @@
+package afib
+
+//go:generate stringer -type=Afib
+//go:generate go run ../../internal/enumgen -type=Afib
+type Afib int
+
+// Afib constants for the nokia health api. They classify an ECG recording
+// for signs of atrial fibrillation.
+const (
+	Negative     Afib = 0
+	Positive     Afib = 1
+	Inconclusive Afib = 2
+)
@@ -0,0 +1,36 @@
+package attrib
+
+//go:generate stringer -type=Attrib
+//go:generate go run ../../internal/enumgen -type=Attrib
+type Attrib int
+
+// Attrib constants for the nokia health api. The attrib of a measure group
+// describes how the measures were captured and who they are attributed to.
+const (
+	DeviceEntryForUser              Attrib = 0
+	DeviceEntryForUserAmbiguous     Attrib = 1
+	ManualUserEntry                 Attrib = 2
+	ManualUserDuringAccountCreation Attrib = 4
+	MeasureAuto                     Attrib = 5
+	MeasureUserConfirmed            Attrib = 7
+	SameAsDeviceEntryForUser        Attrib = 8
+)
+
+// IsDevice returns true if the measures were captured by a device.
+func (i Attrib) IsDevice() bool {
+	switch i {
+	case DeviceEntryForUser, DeviceEntryForUserAmbiguous, MeasureAuto, MeasureUserConfirmed, SameAsDeviceEntryForUser:
+		return true
+	}
+	return false
+}
+
+// IsManual returns true if the measures were entered manually by the user.
+func (i Attrib) IsManual() bool {
+	return i == ManualUserEntry || i == ManualUserDuringAccountCreation
+}
+
+// IsAmbiguous returns true if the measures may belong to another user.
+func (i Attrib) IsAmbiguous() bool {
+	return i == DeviceEntryForUserAmbiguous
+}
@@ -0,0 +1,12 @@
+package category
+
+//go:generate stringer -type=Category
+//go:generate go run ../../internal/enumgen -type=Category
+type Category int
+
+// Category constants for the nokia health api. Measure groups are either real
+// measurements or objectives set by the user.
+const (
+	RealMeasurement Category = 1
+	UserObjective   Category = 2
+)
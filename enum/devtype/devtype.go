@@ -1,13 +1,42 @@
 package devtype
 
+import (
+	"fmt"
+	"strings"
+)
+
 //go:generate stringer -type=DevType
+//go:generate go run ../../internal/enumgen -type=DevType
 type DevType int
 
-// DevType constants for the nokia health api.
+// DevType constants for the nokia health api. Thermometer is only reported by
+// the user devices and cannot be used to filter measures.
 const (
 	UserRelated          DevType = 0
-	BodyScale                    = 1
-	BloodPressureMonitor         = 4
-	ActivityTracker              = 16
-	SleepMonitor                 = 32
+	BodyScale            DevType = 1
+	BloodPressureMonitor DevType = 4
+	ActivityTracker      DevType = 16
+	SleepMonitor         DevType = 32
+	Thermometer          DevType = 64
 )
+
+// deviceTypes maps the device type names used by the user devices to their
+// DevType. The other device types, such as Babyphone or Gateway, have no
+// numeric value in the API and are only available as the name.
+var deviceTypes = map[string]DevType{
+	"scale":                       BodyScale,
+	"blood pressure monitor":      BloodPressureMonitor,
+	"activity tracker":            ActivityTracker,
+	"sleep monitor":               SleepMonitor,
+	"smart connected thermometer": Thermometer,
+}
+
+// ParseDeviceType returns the DevType of the device type name returned with
+// the user devices such as "Blood Pressure Monitor". Names are matched case
+// insensitively.
+func ParseDeviceType(s string) (DevType, error) {
+	if v, ok := deviceTypes[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("%q is not a known device type", s)
+}
@@ -0,0 +1,21 @@
+package devtype
+
+import "testing"
+
+func TestParseDeviceType(t *testing.T) {
+	tests := map[string]DevType{
+		"Scale":                       BodyScale,
+		"Blood Pressure Monitor":      BloodPressureMonitor,
+		"activity tracker":            ActivityTracker,
+		"Smart Connected Thermometer": Thermometer,
+	}
+	for name, want := range tests {
+		got, err := ParseDeviceType(name)
+		if err != nil || got != want {
+			t.Errorf("expected %s for %q got %s: %v", want, name, got, err)
+		}
+	}
+	if _, err := ParseDeviceType("Toaster"); err == nil {
+		t.Errorf("expected an error for an unknown device type")
+	}
+}
@@ -1,24 +1,112 @@
 package meastype
 
 //go:generate stringer -type=MeasType
+//go:generate go run ../../internal/enumgen -type=MeasType
 type MeasType int
 
 // MeasType constants for the nokia health api.
 const (
-	Weight                     MeasType = 1
-	Height                              = 4
-	FatFreeMassKg                       = 5
-	FatRatio                            = 6
-	FatMassWeightKg                     = 8
-	DiastolicBloodPressureMMHG          = 9
-	SystolicBloodPressureMMHG           = 10
-	HeartPulseBPM                       = 11
-	Temperature                         = 12
-	SP02Percent                         = 54
-	BodyTemperature                     = 71
-	SkinTemperature                     = 73
-	MuscleMass                          = 76
-	Hydration                           = 77
-	BoneMass                            = 88
-	PulseWaveVelocity                   = 91
+	Weight                         MeasType = 1
+	Height                         MeasType = 4
+	FatFreeMassKg                  MeasType = 5
+	FatRatio                       MeasType = 6
+	FatMassWeightKg                MeasType = 8
+	DiastolicBloodPressureMMHG     MeasType = 9
+	SystolicBloodPressureMMHG      MeasType = 10
+	HeartPulseBPM                  MeasType = 11
+	Temperature                    MeasType = 12
+	SP02Percent                    MeasType = 54
+	BodyTemperature                MeasType = 71
+	SkinTemperature                MeasType = 73
+	MuscleMass                     MeasType = 76
+	Hydration                      MeasType = 77
+	BoneMass                       MeasType = 88
+	PulseWaveVelocity              MeasType = 91
+	VO2Max                         MeasType = 123
+	AtrialFibrillationECG          MeasType = 130
+	QRSIntervalDuration            MeasType = 135
+	PRIntervalDuration             MeasType = 136
+	QTIntervalDuration             MeasType = 137
+	CorrectedQTIntervalDuration    MeasType = 138
+	AtrialFibrillationPPG          MeasType = 139
+	VascularAge                    MeasType = 155
+	NerveHealthScore               MeasType = 167
+	ExtracellularWater             MeasType = 168
+	IntracellularWater             MeasType = 169
+	VisceralFat                    MeasType = 170
+	FatFreeMassSegments            MeasType = 173
+	FatMassSegments                MeasType = 174
+	MuscleMassSegments             MeasType = 175
+	ElectrodermalActivityFeet      MeasType = 196
+	BasalMetabolicRate             MeasType = 226
+	MetabolicAge                   MeasType = 227
+	ElectrochemicalSkinConductance MeasType = 229
 )
+
+// Info describes the unit a MeasType is reported in once the value has been
+// scaled by its unit exponent, along with a human readable description.
+type Info struct {
+	Unit        string
+	Description string
+}
+
+// infos is the unit and description table for every known MeasType.
+var infos = map[MeasType]Info{
+	Weight:                         {"kg", "Weight"},
+	Height:                         {"m", "Height"},
+	FatFreeMassKg:                  {"kg", "Fat free mass"},
+	FatRatio:                       {"%", "Fat ratio"},
+	FatMassWeightKg:                {"kg", "Fat mass weight"},
+	DiastolicBloodPressureMMHG:     {"mmHg", "Diastolic blood pressure"},
+	SystolicBloodPressureMMHG:      {"mmHg", "Systolic blood pressure"},
+	HeartPulseBPM:                  {"bpm", "Heart pulse"},
+	Temperature:                    {"°C", "Temperature"},
+	SP02Percent:                    {"%", "SpO2"},
+	BodyTemperature:                {"°C", "Body temperature"},
+	SkinTemperature:                {"°C", "Skin temperature"},
+	MuscleMass:                     {"kg", "Muscle mass"},
+	Hydration:                      {"kg", "Hydration"},
+	BoneMass:                       {"kg", "Bone mass"},
+	PulseWaveVelocity:              {"m/s", "Pulse wave velocity"},
+	VO2Max:                         {"ml/min/kg", "VO2 max"},
+	AtrialFibrillationECG:          {"", "Atrial fibrillation result (ECG)"},
+	QRSIntervalDuration:            {"ms", "QRS interval duration based on ECG signal"},
+	PRIntervalDuration:             {"ms", "PR interval duration based on ECG signal"},
+	QTIntervalDuration:             {"ms", "QT interval duration based on ECG signal"},
+	CorrectedQTIntervalDuration:    {"ms", "Corrected QT interval duration based on ECG signal"},
+	AtrialFibrillationPPG:          {"", "Atrial fibrillation result from PPG"},
+	VascularAge:                    {"years", "Vascular age"},
+	NerveHealthScore:               {"", "Nerve health score conductance 2 electrodes feet"},
+	ExtracellularWater:             {"kg", "Extracellular water"},
+	IntracellularWater:             {"kg", "Intracellular water"},
+	VisceralFat:                    {"", "Visceral fat"},
+	FatFreeMassSegments:            {"kg", "Fat free mass for segments"},
+	FatMassSegments:                {"kg", "Fat mass for segments"},
+	MuscleMassSegments:             {"kg", "Muscle mass for segments"},
+	ElectrodermalActivityFeet:      {"", "Electrodermal activity feet"},
+	BasalMetabolicRate:             {"kcal", "Basal metabolic rate"},
+	MetabolicAge:                   {"years", "Metabolic age"},
+	ElectrochemicalSkinConductance: {"µS", "Electrochemical skin conductance"},
+}
+
+// Info returns the unit and description of the MeasType. The second return
+// value is false if the type is unknown.
+func (i MeasType) Info() (Info, bool) {
+	info, ok := infos[i]
+	return info, ok
+}
+
+// Unit returns the unit the MeasType is reported in. Unit-less measures and
+// unknown types return an empty string.
+func (i MeasType) Unit() string {
+	return infos[i].Unit
+}
+
+// Description returns a human readable description of the MeasType. Unknown
+// types fall back to the String value.
+func (i MeasType) Description() string {
+	if info, ok := infos[i]; ok {
+		return info.Description
+	}
+	return i.String()
+}
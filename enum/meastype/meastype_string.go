@@ -4,14 +4,90 @@ package meastype
 
 import "strconv"
 
-const _MeasType_name = "Weight"
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Weight-1]
+	_ = x[Height-4]
+	_ = x[FatFreeMassKg-5]
+	_ = x[FatRatio-6]
+	_ = x[FatMassWeightKg-8]
+	_ = x[DiastolicBloodPressureMMHG-9]
+	_ = x[SystolicBloodPressureMMHG-10]
+	_ = x[HeartPulseBPM-11]
+	_ = x[Temperature-12]
+	_ = x[SP02Percent-54]
+	_ = x[BodyTemperature-71]
+	_ = x[SkinTemperature-73]
+	_ = x[MuscleMass-76]
+	_ = x[Hydration-77]
+	_ = x[BoneMass-88]
+	_ = x[PulseWaveVelocity-91]
+	_ = x[VO2Max-123]
+	_ = x[AtrialFibrillationECG-130]
+	_ = x[QRSIntervalDuration-135]
+	_ = x[PRIntervalDuration-136]
+	_ = x[QTIntervalDuration-137]
+	_ = x[CorrectedQTIntervalDuration-138]
+	_ = x[AtrialFibrillationPPG-139]
+	_ = x[VascularAge-155]
+	_ = x[NerveHealthScore-167]
+	_ = x[ExtracellularWater-168]
+	_ = x[IntracellularWater-169]
+	_ = x[VisceralFat-170]
+	_ = x[FatFreeMassSegments-173]
+	_ = x[FatMassSegments-174]
+	_ = x[MuscleMassSegments-175]
+	_ = x[ElectrodermalActivityFeet-196]
+	_ = x[BasalMetabolicRate-226]
+	_ = x[MetabolicAge-227]
+	_ = x[ElectrochemicalSkinConductance-229]
+}
+
+const _MeasType_name = "WeightHeightFatFreeMassKgFatRatioFatMassWeightKgDiastolicBloodPressureMMHGSystolicBloodPressureMMHGHeartPulseBPMTemperatureSP02PercentBodyTemperatureSkinTemperatureMuscleMassHydrationBoneMassPulseWaveVelocityVO2MaxAtrialFibrillationECGQRSIntervalDurationPRIntervalDurationQTIntervalDurationCorrectedQTIntervalDurationAtrialFibrillationPPGVascularAgeNerveHealthScoreExtracellularWaterIntracellularWaterVisceralFatFatFreeMassSegmentsFatMassSegmentsMuscleMassSegmentsElectrodermalActivityFeetBasalMetabolicRateMetabolicAgeElectrochemicalSkinConductance"
 
-var _MeasType_index = [...]uint8{0, 6}
+var _MeasType_map = map[MeasType]string{
+	1:   _MeasType_name[0:6],
+	4:   _MeasType_name[6:12],
+	5:   _MeasType_name[12:25],
+	6:   _MeasType_name[25:33],
+	8:   _MeasType_name[33:48],
+	9:   _MeasType_name[48:74],
+	10:  _MeasType_name[74:99],
+	11:  _MeasType_name[99:112],
+	12:  _MeasType_name[112:123],
+	54:  _MeasType_name[123:134],
+	71:  _MeasType_name[134:149],
+	73:  _MeasType_name[149:164],
+	76:  _MeasType_name[164:174],
+	77:  _MeasType_name[174:183],
+	88:  _MeasType_name[183:191],
+	91:  _MeasType_name[191:208],
+	123: _MeasType_name[208:214],
+	130: _MeasType_name[214:235],
+	135: _MeasType_name[235:254],
+	136: _MeasType_name[254:272],
+	137: _MeasType_name[272:290],
+	138: _MeasType_name[290:317],
+	139: _MeasType_name[317:338],
+	155: _MeasType_name[338:349],
+	167: _MeasType_name[349:365],
+	168: _MeasType_name[365:383],
+	169: _MeasType_name[383:401],
+	170: _MeasType_name[401:412],
+	173: _MeasType_name[412:431],
+	174: _MeasType_name[431:446],
+	175: _MeasType_name[446:464],
+	196: _MeasType_name[464:489],
+	226: _MeasType_name[489:507],
+	227: _MeasType_name[507:519],
+	229: _MeasType_name[519:549],
+}
 
 func (i MeasType) String() string {
-	i -= 1
-	if i < 0 || i >= MeasType(len(_MeasType_index)-1) {
-		return "MeasType(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	if str, ok := _MeasType_map[i]; ok {
+		return str
 	}
-	return _MeasType_name[_MeasType_index[i]:_MeasType_index[i+1]]
+	return "MeasType(" + strconv.FormatInt(int64(i), 10) + ")"
 }
@@ -0,0 +1,21 @@
+package meastype
+
+import "testing"
+
+func TestMeasTypeInfo(t *testing.T) {
+	if VO2Max.String() != "VO2Max" {
+		t.Errorf("expected VO2Max got %s", VO2Max.String())
+	}
+	if PulseWaveVelocity.String() != "PulseWaveVelocity" {
+		t.Errorf("expected PulseWaveVelocity got %s", PulseWaveVelocity.String())
+	}
+	if ExtracellularWater.Unit() != "kg" {
+		t.Errorf("expected kg got %s", ExtracellularWater.Unit())
+	}
+	if MeasType(999).Description() != "MeasType(999)" {
+		t.Errorf("unexpected description for unknown type: %s", MeasType(999).Description())
+	}
+	if _, ok := MeasType(999).Info(); ok {
+		t.Errorf("expected unknown type to have no info")
+	}
+}
@@ -1,11 +1,13 @@
 package sleepstate
 
 //go:generate stringer -type=SleepState
+//go:generate go run ../../internal/enumgen -type=SleepState
 type SleepState int
 
+// SleepState constants for the nokia health api.
 const (
 	Awake      SleepState = 0
-	LightSleep            = 1
-	DeepSleep             = 2
-	REM                   = 3
+	LightSleep SleepState = 1
+	DeepSleep  SleepState = 2
+	REM        SleepState = 3
 )
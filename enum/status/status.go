@@ -1,6 +1,7 @@
 package status
 
 //go:generate stringer -type=Status
+//go:generate go run ../../internal/enumgen -type=Status
 type Status int
 
 const (
@@ -9,7 +10,7 @@ const (
 	TheProvidedUserIDAndOrOauthCredsDoNotMatch Status = 250
 	TokenIsInvalidOrDoesntExist                Status = 283
 	NoSuchSubscription                         Status = 286
-	TheCallbackURLIsEitherAbsentOrIncorrect    Status = 283
+	TheCallbackURLIsEitherAbsentOrIncorrect    Status = 293
 	NoSuchSubscriptionCouldBeDeleted           Status = 294
 	CommentAbsentOrIncorrect                   Status = 304
 	TooManyNotificationsSet                    Status = 305
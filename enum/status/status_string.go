@@ -4,7 +4,29 @@ package status
 
 import "strconv"
 
-const _Status_name = "OperationWasSuccessfulTheUserIDProvidedIsAbsentOrIncorrectTheProvidedUserIDAndOrOauthCredsDoNotMatchTokenIsInvalidOrDoesntExistNoSuchSubscriptionNoSuchSubscriptionCouldBeDeletedCommentAbsentOrIncorrectTooManyNotificationsSetUserIsDeactiviatedSignatureIsInvalidWrongNotificationCallbackURLTooManyRequetsWrongActionOrWrongWebserviceUnknonwErrorServiceNotDefined"
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[OperationWasSuccessful-0]
+	_ = x[TheUserIDProvidedIsAbsentOrIncorrect-247]
+	_ = x[TheProvidedUserIDAndOrOauthCredsDoNotMatch-250]
+	_ = x[TokenIsInvalidOrDoesntExist-283]
+	_ = x[NoSuchSubscription-286]
+	_ = x[TheCallbackURLIsEitherAbsentOrIncorrect-293]
+	_ = x[NoSuchSubscriptionCouldBeDeleted-294]
+	_ = x[CommentAbsentOrIncorrect-304]
+	_ = x[TooManyNotificationsSet-305]
+	_ = x[UserIsDeactiviated-328]
+	_ = x[SignatureIsInvalid-342]
+	_ = x[WrongNotificationCallbackURL-343]
+	_ = x[TooManyRequets-601]
+	_ = x[WrongActionOrWrongWebservice-2554]
+	_ = x[UnknonwError-2555]
+	_ = x[ServiceNotDefined-2556]
+}
+
+const _Status_name = "OperationWasSuccessfulTheUserIDProvidedIsAbsentOrIncorrectTheProvidedUserIDAndOrOauthCredsDoNotMatchTokenIsInvalidOrDoesntExistNoSuchSubscriptionTheCallbackURLIsEitherAbsentOrIncorrectNoSuchSubscriptionCouldBeDeletedCommentAbsentOrIncorrectTooManyNotificationsSetUserIsDeactiviatedSignatureIsInvalidWrongNotificationCallbackURLTooManyRequetsWrongActionOrWrongWebserviceUnknonwErrorServiceNotDefined"
 
 var _Status_map = map[Status]string{
 	0:    _Status_name[0:22],
@@ -12,16 +34,17 @@ var _Status_map = map[Status]string{
 	250:  _Status_name[58:100],
 	283:  _Status_name[100:127],
 	286:  _Status_name[127:145],
-	294:  _Status_name[145:177],
-	304:  _Status_name[177:201],
-	305:  _Status_name[201:224],
-	328:  _Status_name[224:242],
-	342:  _Status_name[242:260],
-	343:  _Status_name[260:288],
-	601:  _Status_name[288:302],
-	2554: _Status_name[302:330],
-	2555: _Status_name[330:342],
-	2556: _Status_name[342:359],
+	293:  _Status_name[145:184],
+	294:  _Status_name[184:216],
+	304:  _Status_name[216:240],
+	305:  _Status_name[240:263],
+	328:  _Status_name[263:281],
+	342:  _Status_name[281:299],
+	343:  _Status_name[299:327],
+	601:  _Status_name[327:341],
+	2554: _Status_name[341:369],
+	2555: _Status_name[369:381],
+	2556: _Status_name[381:398],
 }
 
 func (i Status) String() string {
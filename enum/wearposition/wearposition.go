@@ -0,0 +1,17 @@
+package wearposition
+
+//go:generate stringer -type=WearPosition
+//go:generate go run ../../internal/enumgen -type=WearPosition
+type WearPosition int
+
+// WearPosition constants for the nokia health api. They describe where the
+// device was worn when a signal was recorded.
+const (
+	RightWrist  WearPosition = 0
+	LeftWrist   WearPosition = 1
+	RightArm    WearPosition = 2
+	LeftArm     WearPosition = 3
+	RightFoot   WearPosition = 4
+	LeftFoot    WearPosition = 5
+	BetweenLegs WearPosition = 6
+)
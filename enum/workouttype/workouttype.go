@@ -1,6 +1,7 @@
 package workouttype
 
 //go:generate stringer -type=WorkoutType
+//go:generate go run ../../internal/enumgen -type=WorkoutType
 type WorkoutType int
 
 // WorkoutType constants for the nokia health api.
@@ -40,12 +41,19 @@ const (
 	MartialArts  WorkoutType = 33
 	Skiing       WorkoutType = 34
 	SnowBoarding WorkoutType = 35
+	Other        WorkoutType = 36
+	NoActivity   WorkoutType = 128
 	Base         WorkoutType = 186
 	Rowing       WorkoutType = 187
 	Zumba        WorkoutType = 188
 	Baseball     WorkoutType = 191
 	Handball     WorkoutType = 192
+	FieldHockey  WorkoutType = 193
 	Hockey       WorkoutType = 194
 	Climbing     WorkoutType = 195
 	IceSkating   WorkoutType = 196
+	MultiSport   WorkoutType = 272
+	IndoorWalk   WorkoutType = 306
+	IndoorRun    WorkoutType = 307
+	IndoorCycle  WorkoutType = 308
 )
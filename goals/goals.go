@@ -0,0 +1,193 @@
+// Package goals tracks daily activity goals, streaks and weekly and monthly
+// rollups from the activity measures returned by the Nokia Health API.
+//
+// Days are identified by the civil date reported by the API in the time zone
+// of the activity so a day is never shifted when the user travels.
+package goals
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// Goals are the daily targets. Zero values are not tracked.
+type Goals struct {
+	Steps float64
+	// ActiveMinutes is the number of minutes of moderate and intense
+	// activity.
+	ActiveMinutes float64
+	Calories      float64
+}
+
+// DefaultGoals are commonly used daily targets.
+var DefaultGoals = Goals{Steps: 10000, ActiveMinutes: 30}
+
+// FromUser returns the default goals with the step goal replaced by the one
+// set by the user, as returned by User.GetGoals.
+func FromUser(g nokiahealth.UserGoals) Goals {
+	goals := DefaultGoals
+	if g.Steps != nil {
+		goals.Steps = float64(*g.Steps)
+	}
+	return goals
+}
+
+// Day is the goal achievement of a single day.
+type Day struct {
+	// Date is midnight of the day in the time zone of the activity.
+	Date          time.Time
+	TimeZone      string
+	Steps         float64
+	ActiveMinutes float64
+	Calories      float64
+
+	StepsMet         bool
+	ActiveMinutesMet bool
+	CaloriesMet      bool
+	// Achieved is true if every tracked goal was met.
+	Achieved bool
+}
+
+// Report is the goal tracking over a run of days.
+type Report struct {
+	Goals Goals
+	// Days are sorted by date and contain one entry per day with activity.
+	Days []Day
+	// CurrentStreak is the number of consecutive days ending at the last day
+	// where the goals were achieved.
+	CurrentStreak int
+	LongestStreak int
+	Weeks         []Rollup
+	Months        []Rollup
+}
+
+// Rollup aggregates the days of a week or month.
+type Rollup struct {
+	// Start is the first day of the period. Weeks start on Monday.
+	Start         time.Time
+	Days          int
+	DaysAchieved  int
+	Steps         float64
+	ActiveMinutes float64
+	Calories      float64
+}
+
+// civilDate returns midnight of the activity date in its time zone. Unknown
+// time zones fall back to UTC.
+func civilDate(a nokiahealth.Activity) (time.Time, bool) {
+	d, err := nokiahealth.ParseCivilDate(a.Date, a.TimeZone)
+	return d, err == nil
+}
+
+// Track computes the goal achievement of the activities in the responses.
+// Responses may overlap, if a date is found more than once the last one
+// wins.
+func Track(responses []nokiahealth.ActivitiesMeasuresResp, goals Goals) Report {
+	var all []nokiahealth.Activity
+	for _, resp := range responses {
+		all = append(all, resp.Activities()...)
+	}
+	return TrackActivities(all, goals)
+}
+
+// TrackActivities computes the goal achievement of the activities provided.
+func TrackActivities(acts []nokiahealth.Activity, goals Goals) Report {
+	r := Report{Goals: goals}
+
+	byDate := map[string]Day{}
+	for _, a := range acts {
+		d, ok := civilDate(a)
+		if !ok {
+			continue
+		}
+		byDate[a.Date] = newDay(d, a, goals)
+	}
+	for _, d := range byDate {
+		r.Days = append(r.Days, d)
+	}
+	sort.Slice(r.Days, func(i, j int) bool {
+		return civil(r.Days[i].Date).Before(civil(r.Days[j].Date))
+	})
+
+	r.CurrentStreak, r.LongestStreak = streaks(r.Days)
+	r.Weeks = rollup(r.Days, weekStart)
+	r.Months = rollup(r.Days, monthStart)
+
+	return r
+}
+
+func newDay(date time.Time, a nokiahealth.Activity, goals Goals) Day {
+	d := Day{
+		Date:          date,
+		TimeZone:      a.TimeZone,
+		Steps:         a.Steps,
+		ActiveMinutes: float64(a.Moderate+a.Intense) / 60,
+		Calories:      a.Calories,
+	}
+	d.StepsMet = goals.Steps <= 0 || d.Steps >= goals.Steps
+	d.ActiveMinutesMet = goals.ActiveMinutes <= 0 || d.ActiveMinutes >= goals.ActiveMinutes
+	d.CaloriesMet = goals.Calories <= 0 || d.Calories >= goals.Calories
+	d.Achieved = d.StepsMet && d.ActiveMinutesMet && d.CaloriesMet
+	return d
+}
+
+// civil returns the civil date of the time as midnight UTC so days in
+// different time zones can be compared and counted.
+func civil(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// streaks returns the current and longest streak of achieved days. A missing
+// day breaks the streak.
+func streaks(days []Day) (current int, longest int) {
+	run := 0
+	for i, d := range days {
+		switch {
+		case !d.Achieved:
+			run = 0
+		case i > 0 && civil(days[i-1].Date).AddDate(0, 0, 1).Equal(civil(d.Date)) && days[i-1].Achieved:
+			run++
+		default:
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return run, longest
+}
+
+// weekStart returns the Monday of the week of the civil date.
+func weekStart(t time.Time) time.Time {
+	c := civil(t)
+	offset := (int(c.Weekday()) + 6) % 7
+	return c.AddDate(0, 0, -offset)
+}
+
+// monthStart returns the first day of the month of the civil date.
+func monthStart(t time.Time) time.Time {
+	c := civil(t)
+	return time.Date(c.Year(), c.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// rollup aggregates the sorted days by the period start provided.
+func rollup(days []Day, start func(time.Time) time.Time) []Rollup {
+	var rollups []Rollup
+	for _, d := range days {
+		s := start(d.Date)
+		if len(rollups) == 0 || !rollups[len(rollups)-1].Start.Equal(s) {
+			rollups = append(rollups, Rollup{Start: s})
+		}
+		r := &rollups[len(rollups)-1]
+		r.Days++
+		if d.Achieved {
+			r.DaysAchieved++
+		}
+		r.Steps += d.Steps
+		r.ActiveMinutes += d.ActiveMinutes
+		r.Calories += d.Calories
+	}
+	return rollups
+}
@@ -0,0 +1,68 @@
+package goals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+func TestTrack(t *testing.T) {
+	resp := nokiahealth.ActivitiesMeasuresResp{
+		Body: &nokiahealth.ActivitiesMeasuresRespBody{
+			Activities: []nokiahealth.Activity{
+				{Date: "2018-07-02", TimeZone: "America/New_York", Steps: 12000, Moderate: 1200, Intense: 900},
+				{Date: "2018-07-01", TimeZone: "America/New_York", Steps: 11000, Moderate: 1800},
+				{Date: "2018-06-30", TimeZone: "Europe/Paris", Steps: 4000, Moderate: 3600},
+				{Date: "2018-06-29", TimeZone: "Europe/Paris", Steps: 15000, Moderate: 1800},
+				{Date: "2018-06-28", TimeZone: "Europe/Paris", Steps: 15000, Moderate: 1800},
+				{Date: "2018-06-27", TimeZone: "Europe/Paris", Steps: 15000, Intense: 1800},
+			},
+		},
+	}
+	// An overlapping response replacing a day.
+	later := nokiahealth.ActivitiesMeasuresResp{
+		Body: &nokiahealth.ActivitiesMeasuresRespBody{
+			Activities: []nokiahealth.Activity{
+				{Date: "2018-07-04", TimeZone: "America/New_York", Steps: 10500, Moderate: 1800},
+				{Date: "2018-07-02", TimeZone: "America/New_York", Steps: 2000},
+			},
+		},
+	}
+
+	r := Track([]nokiahealth.ActivitiesMeasuresResp{resp, later}, DefaultGoals)
+
+	if len(r.Days) != 7 {
+		t.Fatalf("expected 7 days got %d", len(r.Days))
+	}
+	if r.Days[0].Date.Format("2006-01-02") != "2018-06-27" || r.Days[0].Date.Location().String() != "Europe/Paris" {
+		t.Errorf("unexpected first day %s", r.Days[0].Date)
+	}
+	if r.Days[5].Achieved || r.Days[5].Steps != 2000 {
+		t.Errorf("expected the later response to replace 2018-07-02 got %+v", r.Days[5])
+	}
+	if r.LongestStreak != 3 {
+		t.Errorf("expected longest streak of 3 got %d", r.LongestStreak)
+	}
+	// 2018-07-03 is missing so the streak restarts.
+	if r.CurrentStreak != 1 {
+		t.Errorf("expected current streak of 1 got %d", r.CurrentStreak)
+	}
+
+	if len(r.Weeks) != 2 || r.Weeks[0].Days != 5 || r.Weeks[0].DaysAchieved != 4 {
+		t.Errorf("unexpected weeks %+v", r.Weeks)
+	}
+	if len(r.Months) != 2 || r.Months[1].Start != time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC) || r.Months[1].Steps != 23500 {
+		t.Errorf("unexpected months %+v", r.Months)
+	}
+}
+
+func TestFromUser(t *testing.T) {
+	if g := FromUser(nokiahealth.UserGoals{}); g != DefaultGoals {
+		t.Errorf("expected the default goals got %+v", g)
+	}
+	steps := 7500
+	if g := FromUser(nokiahealth.UserGoals{Steps: &steps}); g.Steps != 7500 || g.ActiveMinutes != DefaultGoals.ActiveMinutes {
+		t.Errorf("expected the user step goal got %+v", g)
+	}
+}
@@ -0,0 +1,258 @@
+package nokiahealth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth/enum/sleepstate"
+)
+
+// DefaultNightGap is the default gap between sleep measures that starts a new
+// night in a hypnogram.
+const DefaultNightGap = 3 * time.Hour
+
+// HypnogramOptions configures how a hypnogram is built from sleep measures.
+type HypnogramOptions struct {
+	// NightGap is the minimum gap without measures that separates two
+	// nights. Smaller gaps are filled as awake. Defaults to DefaultNightGap.
+	NightGap time.Duration
+	// Location is the location the times of the segments are in. Defaults
+	// to the location the dates of the response were parsed in, see
+	// SleepMeasuresQueryParam.Location, or time.Local if they were not.
+	Location *time.Location
+}
+
+// HypnogramSegment is a period of time spent in a single sleep state.
+type HypnogramSegment struct {
+	Start time.Time
+	End   time.Time
+	State sleepstate.SleepState
+}
+
+// Duration returns the length of the segment.
+func (s HypnogramSegment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// HypnogramNight is a contiguous and ordered sleep state timeline for one
+// night. Adjacent segments never share the same state.
+type HypnogramNight struct {
+	Start    time.Time
+	End      time.Time
+	Segments []HypnogramSegment
+}
+
+// Hypnogram is the sleep state timeline of one or more nights.
+type Hypnogram struct {
+	Nights []HypnogramNight
+}
+
+// Hypnogram builds a hypnogram from the sleep measures of the response. The
+// measures may be unordered and overlap. When measures overlap the one that
+// started last takes precedence for the overlapping period. Gaps shorter than
+// the night gap are filled as awake while longer gaps start a new night. If
+// opts is nil the defaults are used.
+func (rm SleepMeasuresResp) Hypnogram(opts *HypnogramOptions) Hypnogram {
+	if opts == nil {
+		opts = &HypnogramOptions{}
+	}
+	nightGap := opts.NightGap
+	if nightGap <= 0 {
+		nightGap = DefaultNightGap
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = rm.location()
+	}
+
+	h := Hypnogram{}
+	if rm.Body == nil {
+		return h
+	}
+
+	// Order the measures by start so later starts take precedence.
+	measures := make([]SleepMeasure, 0, len(rm.Body.Series))
+	for _, m := range rm.Body.Series {
+		if m.EndDate > m.StartDate {
+			measures = append(measures, m)
+		}
+	}
+	sort.SliceStable(measures, func(i, j int) bool {
+		if measures[i].StartDate != measures[j].StartDate {
+			return measures[i].StartDate < measures[j].StartDate
+		}
+		return measures[i].EndDate < measures[j].EndDate
+	})
+
+	// Split the timeline on every boundary and find the state of each slice.
+	var bounds []int64
+	for _, m := range measures {
+		bounds = append(bounds, m.StartDate, m.EndDate)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	var segments []HypnogramSegment
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if start == end {
+			continue
+		}
+
+		found := false
+		var state sleepstate.SleepState
+		for _, m := range measures {
+			if m.StartDate > start {
+				break
+			}
+			if m.EndDate > start {
+				state = m.State
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+
+		segments = appendSegment(segments, HypnogramSegment{
+			Start: time.Unix(start, 0).In(loc),
+			End:   time.Unix(end, 0).In(loc),
+			State: state,
+		})
+	}
+
+	// Split into nights filling the small gaps.
+	var night *HypnogramNight
+	for _, s := range segments {
+		if night != nil && s.Start.Sub(night.End) >= nightGap {
+			h.Nights = append(h.Nights, *night)
+			night = nil
+		}
+		if night == nil {
+			night = &HypnogramNight{Start: s.Start}
+		} else if s.Start.After(night.End) {
+			night.Segments = appendSegment(night.Segments, HypnogramSegment{Start: night.End, End: s.Start, State: sleepstate.Awake})
+		}
+		night.Segments = appendSegment(night.Segments, s)
+		night.End = s.End
+	}
+	if night != nil {
+		h.Nights = append(h.Nights, *night)
+	}
+
+	return h
+}
+
+// location returns the location the dates of the measures were parsed in or
+// time.Local if they were not parsed.
+func (rm SleepMeasuresResp) location() *time.Location {
+	if rm.Body != nil {
+		for _, m := range rm.Body.Series {
+			if m.StartDateParsed != nil {
+				return m.StartDateParsed.Location()
+			}
+		}
+	}
+	return time.Local
+}
+
+// appendSegment appends the segment merging it with the last one if they are
+// contiguous and share the same state.
+func appendSegment(segments []HypnogramSegment, s HypnogramSegment) []HypnogramSegment {
+	if n := len(segments); n > 0 && segments[n-1].State == s.State && segments[n-1].End.Equal(s.Start) {
+		segments[n-1].End = s.End
+		return segments
+	}
+	return append(segments, s)
+}
+
+// Duration returns the time spent in the state during the night.
+func (n HypnogramNight) Duration(state sleepstate.SleepState) time.Duration {
+	var d time.Duration
+	for _, s := range n.Segments {
+		if s.State == state {
+			d += s.Duration()
+		}
+	}
+	return d
+}
+
+// Transitions returns the number of times the state changed during the night.
+func (n HypnogramNight) Transitions() int {
+	if len(n.Segments) == 0 {
+		return 0
+	}
+	return len(n.Segments) - 1
+}
+
+// Awakenings returns the number of times the user woke up after falling
+// asleep. Being awake before falling asleep or after the last sleep segment
+// is not counted.
+func (n HypnogramNight) Awakenings() int {
+	count := 0
+	asleep := false
+	for i, s := range n.Segments {
+		if s.State != sleepstate.Awake {
+			asleep = true
+			continue
+		}
+		if asleep && i < len(n.Segments)-1 {
+			count++
+		}
+	}
+	return count
+}
+
+// StateAt returns the state at the time provided. The second return value is
+// false if the time is outside of the night.
+func (n HypnogramNight) StateAt(t time.Time) (sleepstate.SleepState, bool) {
+	i := sort.Search(len(n.Segments), func(i int) bool {
+		return n.Segments[i].End.After(t)
+	})
+	if i == len(n.Segments) || t.Before(n.Segments[i].Start) {
+		return sleepstate.Awake, false
+	}
+	return n.Segments[i].State, true
+}
+
+// Resample renders the night at a fixed resolution such as one state per
+// minute. Each bucket starts at the night start plus a multiple of the
+// resolution and takes the state that covers most of it. Ties go to the
+// earliest state in the bucket.
+func (n HypnogramNight) Resample(resolution time.Duration) []sleepstate.SleepState {
+	if resolution <= 0 || len(n.Segments) == 0 {
+		return nil
+	}
+
+	var states []sleepstate.SleepState
+	seg := 0
+	for start := n.Start; start.Before(n.End); start = start.Add(resolution) {
+		end := start.Add(resolution)
+		if end.After(n.End) {
+			end = n.End
+		}
+
+		var best sleepstate.SleepState
+		var bestDuration time.Duration
+		covered := map[sleepstate.SleepState]time.Duration{}
+		for seg < len(n.Segments) && !n.Segments[seg].End.After(start) {
+			seg++
+		}
+		for i := seg; i < len(n.Segments) && n.Segments[i].Start.Before(end); i++ {
+			s := n.Segments[i]
+			from, to := s.Start, s.End
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			covered[s.State] += to.Sub(from)
+			if covered[s.State] > bestDuration {
+				best = s.State
+				bestDuration = covered[s.State]
+			}
+		}
+		states = append(states, best)
+	}
+	return states
+}
@@ -0,0 +1,88 @@
+package nokiahealth
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth/enum/sleepstate"
+)
+
+func TestHypnogram(t *testing.T) {
+	base := time.Date(2018, 6, 1, 22, 0, 0, 0, time.UTC).Unix()
+	minute := int64(60)
+
+	resp := SleepMeasuresResp{
+		Body: &SleepMeasuresRespBody{
+			Series: []SleepMeasure{
+				// Unordered with an overlap and a small gap.
+				{StartDate: base + 10*minute, EndDate: base + 30*minute, State: sleepstate.DeepSleep},
+				{StartDate: base, EndDate: base + 20*minute, State: sleepstate.LightSleep},
+				{StartDate: base + 35*minute, EndDate: base + 40*minute, State: sleepstate.REM},
+				{StartDate: base + 40*minute, EndDate: base + 45*minute, State: sleepstate.Awake},
+				// A second night.
+				{StartDate: base + 24*60*minute, EndDate: base + 24*60*minute + 10*minute, State: sleepstate.LightSleep},
+			},
+		},
+	}
+
+	h := resp.Hypnogram(&HypnogramOptions{Location: time.UTC})
+
+	if len(h.Nights) != 2 {
+		t.Fatalf("expected 2 nights got %d", len(h.Nights))
+	}
+
+	n := h.Nights[0]
+	want := []sleepstate.SleepState{sleepstate.LightSleep, sleepstate.DeepSleep, sleepstate.Awake, sleepstate.REM, sleepstate.Awake}
+	var got []sleepstate.SleepState
+	for _, s := range n.Segments {
+		got = append(got, s.State)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected states %v got %v", want, got)
+	}
+	if n.Segments[1].Duration() != 20*time.Minute {
+		t.Errorf("expected deep sleep to take precedence for 20 minutes got %s", n.Segments[1].Duration())
+	}
+	if n.Transitions() != 4 || n.Awakenings() != 1 {
+		t.Errorf("expected 4 transitions and 1 awakening got %d and %d", n.Transitions(), n.Awakenings())
+	}
+	if n.Duration(sleepstate.Awake) != 10*time.Minute {
+		t.Errorf("expected 10 minutes awake got %s", n.Duration(sleepstate.Awake))
+	}
+
+	r := n.Resample(15 * time.Minute)
+	wantResampled := []sleepstate.SleepState{sleepstate.LightSleep, sleepstate.DeepSleep, sleepstate.Awake}
+	if !reflect.DeepEqual(r, wantResampled) {
+		t.Errorf("expected resampled %v got %v", wantResampled, r)
+	}
+}
+
+func TestHypnogramLocation(t *testing.T) {
+	tokyo, _ := LoadLocation("Asia/Tokyo")
+	start := time.Date(2018, 6, 1, 22, 0, 0, 0, tokyo)
+	resp := SleepMeasuresResp{
+		Body: &SleepMeasuresRespBody{
+			Series: []SleepMeasure{
+				{StartDate: start.Unix(), EndDate: start.Add(time.Hour).Unix(), State: sleepstate.LightSleep, StartDateParsed: &start,
+					HR: map[int64]float64{start.Unix(): 55}},
+			},
+		},
+	}
+
+	// The location the response was parsed in is used by default.
+	h := resp.Hypnogram(nil)
+	if len(h.Nights) != 1 || h.Nights[0].Start.Location() != tokyo || h.Nights[0].Start.Hour() != 22 {
+		t.Errorf("expected the night in the location of the response got %+v", h.Nights)
+	}
+
+	h = resp.Hypnogram(&HypnogramOptions{Location: time.UTC})
+	if h.Nights[0].Start.Location() != time.UTC {
+		t.Errorf("expected the location of the options to take precedence")
+	}
+
+	// The samples share the location of the hypnogram.
+	if hr := resp.HeartRate(); len(hr) != 1 || hr[0].Date.Location() != tokyo || hr[0].Date.Hour() != 22 {
+		t.Errorf("expected the samples in the location of the response got %+v", hr)
+	}
+}
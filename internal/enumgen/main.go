@@ -0,0 +1,293 @@
+// Command enumgen generates the Values, IsValid and Parse functions along with
+// the text and JSON marshalling of an enum type and their tests. The list of
+// values is taken from the constants of the type so it never gets out of date
+// when the enum is regenerated. It is meant to be run with go generate next to
+// stringer whose String method it relies on.
+//
+//	//go:generate stringer -type=Attrib
+//	//go:generate go run ../../internal/enumgen -type=Attrib
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	typ := flag.String("type", "", "name of the enum type")
+	flag.Parse()
+	if *typ == "" {
+		log.Fatal("enumgen: -type is required")
+	}
+
+	files, err := generate(".", *typ)
+	if err != nil {
+		log.Fatalf("enumgen: %s", err)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(name, src, 0644); err != nil {
+			log.Fatalf("enumgen: failed to write %s: %s", name, err)
+		}
+	}
+}
+
+// enum is the data the templates are executed with.
+type enum struct {
+	Package string
+	Type    string
+	Values  []string
+}
+
+// generate returns the generated files of the type declared in the package
+// found in dir keyed by their path.
+func generate(dir string, typ string) (map[string][]byte, error) {
+	e, err := parseEnum(dir, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for suffix, tmpl := range map[string]*template.Template{"_enum.go": enumTemplate, "_enum_test.go": testTemplate} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, e); err != nil {
+			return nil, fmt.Errorf("failed to execute template: %s", err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to format generated code: %s", err)
+		}
+		files[filepath.Join(dir, strings.ToLower(typ)+suffix)] = src
+	}
+	return files, nil
+}
+
+// parseEnum collects the exported constants of the type in the order they are
+// declared in the non test files of the package.
+func parseEnum(dir string, typ string) (enum, error) {
+	e := enum{Type: typ}
+
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return e, err
+	}
+	sort.Strings(names)
+
+	fset := token.NewFileSet()
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return e, fmt.Errorf("failed to parse %s: %s", name, err)
+		}
+		e.Package = f.Name.Name
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			// A spec without type nor value repeats the previous one.
+			var specType string
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if vs.Type != nil {
+					specType = ""
+					if ident, ok := vs.Type.(*ast.Ident); ok {
+						specType = ident.Name
+					}
+				} else if len(vs.Values) > 0 {
+					specType = ""
+				}
+				if specType != typ {
+					continue
+				}
+				for _, n := range vs.Names {
+					if n.IsExported() {
+						e.Values = append(e.Values, n.Name)
+					}
+				}
+			}
+		}
+	}
+
+	if len(e.Values) == 0 {
+		return e, fmt.Errorf("no constants of type %s found", typ)
+	}
+	return e, nil
+}
+
+var enumTemplate = template.Must(template.New("enum").Parse(`// Code generated by "enumgen -type={{.Type}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// _{{.Type}}_values lists every defined {{.Type}} in the order they are declared.
+var _{{.Type}}_values = []{{.Type}}{
+{{- range .Values}}
+	{{.}},
+{{- end}}
+}
+
+// Values returns every defined {{.Type}}.
+func Values() []{{.Type}} {
+	values := make([]{{.Type}}, len(_{{.Type}}_values))
+	copy(values, _{{.Type}}_values)
+	return values
+}
+
+// IsValid returns true if the {{.Type}} is one of the defined constants.
+func (i {{.Type}}) IsValid() bool {
+	for _, v := range _{{.Type}}_values {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the {{.Type}} matching the name provided. Names are matched
+// case insensitively and the numeric API value is also accepted.
+func Parse(s string) ({{.Type}}, error) {
+	for _, v := range _{{.Type}}_values {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return {{.Type}}(n), nil
+	}
+	return 0, fmt.Errorf("%q is not a valid {{.Type}}", s)
+}
+
+// MarshalText implements encoding.TextMarshaler. Known values are written as
+// their name and unknown values as their number.
+func (i {{.Type}}) MarshalText() ([]byte, error) {
+	if !i.IsValid() {
+		return []byte(strconv.Itoa(int(i))), nil
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *{{.Type}}) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Values are written as the number
+// used by the API so structs mirroring the API marshal like the responses.
+// Use String or MarshalText for the name.
+func (i {{.Type}}) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(i))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The API sends numbers but names
+// are accepted as well.
+func (i *{{.Type}}) UnmarshalJSON(data []byte) error {
+	var n int
+	if err := json.Unmarshal(data, &n); err == nil {
+		*i = {{.Type}}(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{.Type}} should be a number or string, got %s", data)
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`// Code generated by "enumgen -type={{.Type}}"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test{{.Type}}RoundTrip(t *testing.T) {
+	for _, v := range Values() {
+		p, err := Parse(v.String())
+		if err != nil || p != v {
+			t.Errorf("failed to parse %s: %v", v, err)
+		}
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Fatalf("failed to marshal %s: %s", v, err)
+		}
+		var fromText {{.Type}}
+		if err := fromText.UnmarshalText(text); err != nil || fromText != v {
+			t.Errorf("text round trip of %s returned %s: %v", v, fromText, err)
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("failed to marshal %s: %s", v, err)
+		}
+		if string(data) != strconv.Itoa(int(v)) {
+			t.Errorf("expected %s to marshal as its number got %s", v, data)
+		}
+		var fromJSON {{.Type}}
+		if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != v {
+			t.Errorf("json round trip of %s returned %s: %v", v, fromJSON, err)
+		}
+	}
+}
+
+func Test{{.Type}}String(t *testing.T) {
+	seen := map[string]{{.Type}}{}
+	for _, v := range Values() {
+		s := v.String()
+		if strings.HasPrefix(s, "{{.Type}}(") {
+			t.Errorf("%d has no name", int(v))
+		}
+		if other, ok := seen[s]; ok {
+			t.Errorf("%d and %d share the name %s", int(v), int(other), s)
+		}
+		seen[s] = v
+	}
+}
+
+func Test{{.Type}}UnmarshalName(t *testing.T) {
+	v := Values()[len(Values())-1]
+	data, _ := json.Marshal(v.String())
+
+	var got {{.Type}}
+	if err := json.Unmarshal(data, &got); err != nil || got != v {
+		t.Errorf("expected %s from %s got %s: %v", v, data, got, err)
+	}
+
+	unknown := {{.Type}}(-42)
+	text, err := unknown.MarshalText()
+	if err != nil || string(text) != "-42" {
+		t.Errorf("expected unknown value to marshal as a number got %s: %v", text, err)
+	}
+}
+`))
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var directive = regexp.MustCompile(`//go:generate go run \.\./\.\./internal/enumgen -type=(\w+)`)
+
+// TestGeneratedUpToDate ensures every enum package generates its files with
+// enumgen and that they were regenerated after the constants last changed.
+func TestGeneratedUpToDate(t *testing.T) {
+	dirs, err := filepath.Glob("../../enum/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		src, err := os.ReadFile(filepath.Join(dir, filepath.Base(dir)+".go"))
+		if err != nil {
+			t.Fatalf("failed to read %s: %s", dir, err)
+		}
+		m := directive.FindSubmatch(src)
+		if m == nil {
+			t.Errorf("%s has no enumgen directive", dir)
+			continue
+		}
+
+		files, err := generate(dir, string(m[1]))
+		if err != nil {
+			t.Fatalf("failed to generate %s: %s", dir, err)
+		}
+		for name, want := range files {
+			got, err := os.ReadFile(name)
+			if err != nil || !bytes.Equal(got, want) {
+				t.Errorf("%s is out of date, run go generate ./enum/...", name)
+			}
+		}
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	dir := t.TempDir()
+	src := `package color
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	blue
+)
+
+const Unrelated = 3
+
+const (
+	Black Color = 10
+	White Color = 11
+)
+`
+	if err := os.WriteFile(filepath.Join(dir, "color.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := parseEnum(dir, "Color")
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	want := []string{"Red", "Green", "Black", "White"}
+	if e.Package != "color" || len(e.Values) != len(want) {
+		t.Fatalf("unexpected enum %+v", e)
+	}
+	for i := range want {
+		if e.Values[i] != want[i] {
+			t.Errorf("expected %v got %v", want, e.Values)
+		}
+	}
+
+	if _, err := parseEnum(dir, "Shape"); err == nil {
+		t.Errorf("expected an error for a type without constants")
+	}
+}
@@ -0,0 +1,202 @@
+package nokiahealth
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IntradayOptions configures how intraday activity is grouped.
+type IntradayOptions struct {
+	// Location is the location used to align the buckets and to find the
+	// day of each sample. Defaults to the location of the response, see
+	// IntradayActivityQueryParam.Location.
+	Location *time.Location
+	// FillGaps adds empty buckets for the periods without any sample
+	// between the first and last bucket.
+	FillGaps bool
+}
+
+func (o *IntradayOptions) location(fallback *time.Location) *time.Location {
+	if o == nil || o.Location == nil {
+		return fallback
+	}
+	return o.Location
+}
+
+// IntradaySample is a single intraday activity at the time it started.
+type IntradaySample struct {
+	Date time.Time
+	IntraDayActivity
+}
+
+// Samples returns the intraday activity of the response ordered by date. The
+// dates are in the location of the response.
+func (rm IntradayActivityResp) Samples() []IntradaySample {
+	var samples []IntradaySample
+	if rm.Body == nil {
+		return samples
+	}
+
+	loc := rm.location()
+	for ts, a := range rm.Body.Series {
+		samples = append(samples, IntradaySample{Date: time.Unix(ts, 0).In(loc), IntraDayActivity: a})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Date.Before(samples[j].Date) })
+	return samples
+}
+
+// location returns the location of the response or time.Local if it has none.
+func (rm IntradayActivityResp) location() *time.Location {
+	if rm.Location == nil {
+		return time.Local
+	}
+	return rm.Location
+}
+
+// IntradayBucket is the aggregate of the intraday samples that started within
+// a fixed period. Counters such as steps and calories are the sum of the
+// samples while HeartRate and SpO2 are the mean of the samples reporting them
+// and nil if none did. Samples is zero for the buckets added to fill gaps.
+type IntradayBucket struct {
+	Start     time.Time
+	End       time.Time
+	Samples   int
+	Calories  float64
+	Distance  float64
+	Duration  time.Duration
+	Elevation float64
+	Steps     float64
+	PoolLaps  float64
+	Strokes   float64
+	HeartRate *float64
+	SpO2      *float64
+
+	heartRate mean
+	spO2      mean
+}
+
+// mean is a running average.
+type mean struct {
+	sum float64
+	n   int
+}
+
+// add includes the value in the average and returns the new average.
+func (m *mean) add(v float64) *float64 {
+	m.sum += v
+	m.n++
+	avg := m.sum / float64(m.n)
+	return &avg
+}
+
+// add aggregates the sample into the bucket.
+func (b *IntradayBucket) add(a IntraDayActivity) {
+	b.Samples++
+	if a.Calories != nil {
+		b.Calories += *a.Calories
+	}
+	if a.Distance != nil {
+		b.Distance += *a.Distance
+	}
+	if a.Duration != nil {
+		b.Duration += time.Duration(*a.Duration) * time.Second
+	}
+	if a.Elevation != nil {
+		b.Elevation += *a.Elevation
+	}
+	if a.Steps != nil {
+		b.Steps += float64(*a.Steps)
+	}
+	if a.PoolLap != nil {
+		b.PoolLaps += float64(*a.PoolLap)
+	}
+	if a.Stroke != nil {
+		b.Strokes += float64(*a.Stroke)
+	}
+	if a.HeartRate != nil {
+		b.HeartRate = b.heartRate.add(float64(*a.HeartRate))
+	}
+	if a.SpO2Auto != nil {
+		b.SpO2 = b.spO2.add(*a.SpO2Auto)
+	}
+}
+
+// Resample aggregates the intraday activity into buckets of the resolution
+// provided such as 1, 5, 15 or 60 minutes. Buckets are aligned on the local
+// midnight so the resolution must divide a day evenly. If opts is nil the
+// defaults are used.
+func (rm IntradayActivityResp) Resample(resolution time.Duration, opts *IntradayOptions) ([]IntradayBucket, error) {
+	if resolution <= 0 || (24*time.Hour)%resolution != 0 {
+		return nil, fmt.Errorf("invalid resolution %s: must evenly divide a day", resolution)
+	}
+	loc := opts.location(rm.location())
+
+	var buckets []IntradayBucket
+	for _, s := range rm.Samples() {
+		t := s.Date.In(loc)
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		start := midnight.Add(t.Sub(midnight) / resolution * resolution)
+
+		n := len(buckets)
+		if n == 0 || !buckets[n-1].Start.Equal(start) {
+			if n > 0 && opts != nil && opts.FillGaps {
+				for gap := buckets[n-1].End; gap.Before(start); gap = gap.Add(resolution) {
+					buckets = append(buckets, IntradayBucket{Start: gap, End: gap.Add(resolution)})
+				}
+			}
+			buckets = append(buckets, IntradayBucket{Start: start, End: start.Add(resolution)})
+		}
+		buckets[len(buckets)-1].add(s.IntraDayActivity)
+	}
+	return buckets, nil
+}
+
+// IntradayDay is the total of the intraday activity of a single day. Date is
+// in the YYYY-MM-DD format used by Activity.
+type IntradayDay struct {
+	Date string
+	IntradayBucket
+}
+
+// DailyTotals sums the intraday activity per local day. Days without any
+// sample are not included. If opts is nil the defaults are used.
+func (rm IntradayActivityResp) DailyTotals(opts *IntradayOptions) []IntradayDay {
+	loc := opts.location(rm.location())
+
+	var days []IntradayDay
+	for _, s := range rm.Samples() {
+		t := s.Date.In(loc)
+		date := t.Format("2006-01-02")
+
+		n := len(days)
+		if n == 0 || days[n-1].Date != date {
+			start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+			days = append(days, IntradayDay{
+				Date:           date,
+				IntradayBucket: IntradayBucket{Start: start, End: start.AddDate(0, 0, 1)},
+			})
+		}
+		days[len(days)-1].add(s.IntraDayActivity)
+	}
+	return days
+}
+
+// IntradayDiff is the difference between the daily activity summary and the
+// intraday total of the same day. Positive values mean the summary is higher.
+type IntradayDiff struct {
+	Steps     float64
+	Distance  float64
+	Calories  float64
+	Elevation float64
+}
+
+// Diff compares the day total with the activity summary of the same day.
+func (d IntradayDay) Diff(a Activity) IntradayDiff {
+	return IntradayDiff{
+		Steps:     a.Steps - d.Steps,
+		Distance:  a.Distance - d.Distance,
+		Calories:  a.Calories - d.Calories,
+		Elevation: a.Elevation - d.Elevation,
+	}
+}
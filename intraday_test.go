@@ -0,0 +1,88 @@
+package nokiahealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIntradayResample(t *testing.T) {
+	base := time.Date(2018, 6, 1, 23, 50, 0, 0, time.UTC).Unix()
+	raw := fmt.Sprintf(`{"status":0,"body":{"series":{
+		"%d":{"steps":10,"calories":1.5,"duration":60},
+		"%d":{"steps":20,"distance":15,"heart_rate":80},
+		"%d":{"steps":5,"heart_rate":90,"spo2_auto":97.5},
+		"%d":{"steps":7,"elevation":3}
+	}}}`, base+25*60, base, base+60, base+15*60)
+
+	var resp IntradayActivityResp
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to unmarshal intraday activity: %s", err)
+	}
+
+	samples := resp.Samples()
+	if len(samples) != 4 || samples[0].Date.Unix() != base || *samples[3].Steps != 10 {
+		t.Fatalf("unexpected samples %+v", samples)
+	}
+
+	if _, err := resp.Resample(7*time.Minute, nil); err == nil {
+		t.Errorf("expected an error for a resolution not dividing a day")
+	}
+
+	opts := &IntradayOptions{Location: time.UTC, FillGaps: true}
+	buckets, err := resp.Resample(5*time.Minute, opts)
+	if err != nil {
+		t.Fatalf("failed to resample: %s", err)
+	}
+	if len(buckets) != 6 {
+		t.Fatalf("expected 6 buckets got %d", len(buckets))
+	}
+	if buckets[0].Samples != 2 || buckets[0].Steps != 25 || buckets[0].Distance != 15 {
+		t.Errorf("unexpected first bucket %+v", buckets[0])
+	}
+	if buckets[0].HeartRate == nil || *buckets[0].HeartRate != 85 || buckets[0].SpO2 == nil || *buckets[0].SpO2 != 97.5 {
+		t.Errorf("unexpected mean heart rate or spo2 %v %v", buckets[0].HeartRate, buckets[0].SpO2)
+	}
+	if buckets[1].Samples != 0 || !buckets[1].Start.Equal(buckets[0].End) {
+		t.Errorf("expected an empty bucket to fill the gap got %+v", buckets[1])
+	}
+	if last := buckets[5]; last.Steps != 10 || last.Duration != time.Minute || last.Start.Hour() != 0 || last.HeartRate != nil {
+		t.Errorf("unexpected last bucket %+v", last)
+	}
+
+	hourly, _ := resp.Resample(time.Hour, &IntradayOptions{Location: time.UTC})
+	if len(hourly) != 2 || hourly[0].Steps != 25 || hourly[1].Steps != 17 {
+		t.Errorf("unexpected hourly buckets %+v", hourly)
+	}
+
+	days := resp.DailyTotals(opts)
+	if len(days) != 2 || days[0].Date != "2018-06-01" || days[1].Steps != 17 {
+		t.Fatalf("unexpected daily totals %+v", days)
+	}
+	diff := days[0].Diff(Activity{Date: "2018-06-01", Steps: 30, Distance: 15})
+	if diff.Steps != 5 || diff.Distance != 0 {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+}
+
+func TestIntradayLocation(t *testing.T) {
+	tokyo, _ := LoadLocation("Asia/Tokyo")
+	start := time.Date(2018, 6, 1, 23, 30, 0, 0, tokyo)
+	steps := 10
+	resp := IntradayActivityResp{
+		Body:     &IntradayActivityRespBody{Series: map[int64]IntraDayActivity{start.Unix(): {Steps: &steps}}},
+		Location: tokyo,
+	}
+
+	// The samples and days are in the location of the response by default.
+	if samples := resp.Samples(); len(samples) != 1 || samples[0].Date.Location() != tokyo || samples[0].Date.Hour() != 23 {
+		t.Errorf("expected the samples in the location of the response got %+v", samples)
+	}
+	if days := resp.DailyTotals(nil); len(days) != 1 || days[0].Date != "2018-06-01" {
+		t.Errorf("expected the day in the location of the response got %+v", days)
+	}
+	if days := resp.DailyTotals(&IntradayOptions{Location: time.UTC}); len(days) != 1 || days[0].Date != "2018-06-01" || days[0].Start.Location() != time.UTC {
+		t.Errorf("expected the location of the options to take precedence got %+v", days)
+	}
+}
@@ -0,0 +1,279 @@
+package nokiahealth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth/enum/afib"
+	"github.com/jrmycanady/nokiahealth/enum/devtype"
+	"github.com/jrmycanady/nokiahealth/enum/wearposition"
+	"github.com/jrmycanady/nokiahealth/units"
+	"golang.org/x/oauth2"
+)
+
+// roundTripFunc allows a function to be used as the transport of the user
+// HTTP client so requests never reach the API.
+type roundTripFunc func(r *http.Request) string
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(f(r))),
+		Header:     http.Header{},
+		Request:    r,
+	}, nil
+}
+
+// newMockUser returns a user whose requests are answered by the function
+// provided.
+func newMockUser(t *testing.T, respond func(r *http.Request) string) *User {
+	c := NewClient("id", "secret", "http://localhost")
+	return &User{
+		Client:      &c,
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		HTTPClient:  &http.Client{Transport: roundTripFunc(respond)},
+	}
+}
+
+func TestGetAllWorkouts(t *testing.T) {
+	var offsets []string
+	u := newMockUser(t, func(r *http.Request) string {
+		q := r.URL.Query()
+		if q.Get("action") != "getworkouts" || q.Get("lastupdate") != "1500000000" || q.Get("data_fields") != "calories,steps" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		offsets = append(offsets, q.Get("offset"))
+		switch q.Get("offset") {
+		case "":
+			return `{"status":0,"body":{"series":[{"id":1,"timezone":"UTC","date":"2018-01-01"}],"more":true,"offset":1}}`
+		case "1":
+			return `{"status":0,"body":{"series":[{"id":2,"timezone":"UTC","date":"2018-01-02"}],"more":true,"offset":2}}`
+		}
+		return `{"status":0,"body":{"series":[{"id":3,"timezone":"UTC","date":"2018-01-03"}],"more":false,"offset":0}}`
+	})
+
+	lastUpdate := time.Unix(1500000000, 0)
+	p := WorkoutsQueryParam{LastUpdate: &lastUpdate, DataFields: []WorkoutField{WorkoutFieldCalories, WorkoutFieldSteps}}
+	resp, err := u.GetAllWorkouts(&p)
+	if err != nil {
+		t.Fatalf("failed to get all workouts: %s", err)
+	}
+
+	if len(resp.Body.Series) != 3 || resp.Body.Series[2].ID != 3 || resp.Body.More {
+		t.Errorf("unexpected combined response %+v", resp.Body)
+	}
+	if strings.Join(offsets, ",") != ",1,2" {
+		t.Errorf("unexpected offsets requested %v", offsets)
+	}
+	if p.Offset != nil {
+		t.Errorf("expected params to be left untouched")
+	}
+}
+
+func TestGetAllWorkoutsPartial(t *testing.T) {
+	page := func(id int, offset int) string {
+		return fmt.Sprintf(`{"status":0,"body":{"series":[{"id":%d,"timezone":"UTC","date":"2018-01-0%d"}],"more":true,"offset":%d}}`, id, id, offset)
+	}
+	tests := []struct {
+		name   string
+		pages  map[string]string
+		ids    []int
+		offset int
+	}{
+		{"failing page", map[string]string{"": page(1, 1), "1": `{"status":2555,"error":"unknown"}`}, []int{1}, 1},
+		{"repeated offset", map[string]string{"": page(1, 1), "1": page(2, 1)}, []int{1}, 1},
+		{"offset repeated twice", map[string]string{"": page(1, 1), "1": page(2, 2), "2": page(3, 2)}, []int{1, 2}, 2},
+	}
+
+	for _, test := range tests {
+		u := newMockUser(t, func(r *http.Request) string {
+			return test.pages[r.URL.Query().Get("offset")]
+		})
+
+		resp, err := u.GetAllWorkouts(nil)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if resp.Body == nil || len(resp.Body.Series) != len(test.ids) {
+			t.Fatalf("%s: expected the workouts %v got %+v", test.name, test.ids, resp.Body)
+		}
+		for i, id := range test.ids {
+			if resp.Body.Series[i].ID != id {
+				t.Errorf("%s: expected the workouts %v got %+v", test.name, test.ids, resp.Body.Series)
+			}
+		}
+		// Resuming from the offset reported must not retrieve a workout twice.
+		if !resp.Body.More || resp.Body.Offset != test.offset {
+			t.Errorf("%s: expected the response to report more workouts at offset %d got %+v", test.name, test.offset, resp.Body)
+		}
+	}
+}
+
+func TestGetHeart(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		q := r.URL.Query()
+		if !strings.HasSuffix(r.URL.Path, "/v2/heart") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch q.Get("action") {
+		case "list":
+			return `{"status":0,"body":{"series":[{"deviceid":"abc","model":44,"ecg":{"signalid":12,"afib":2},
+				"heart_rate":71,"timestamp":1540000000,"timezone":"Europe/Paris"}],"more":false,"offset":0}}`
+		case "get":
+			if q.Get("signalid") != "12" {
+				t.Errorf("unexpected signal requested %s", r.URL.RawQuery)
+			}
+			return `{"status":0,"body":{"signal":[1,-2,3,4],"sampling_frequency":2,"wearposition":1}}`
+		}
+		t.Errorf("unexpected query %s", r.URL.RawQuery)
+		return `{"status":2555}`
+	})
+
+	list, err := u.GetHeartList(nil)
+	if err != nil {
+		t.Fatalf("failed to get heart list: %s", err)
+	}
+	rec := list.Body.Series[0]
+	if rec.ECG.Afib != afib.Inconclusive || rec.HeartRate != 71 || rec.TimestampParsed == nil || rec.TimestampParsed.Unix() != 1540000000 {
+		t.Errorf("unexpected recording %+v", rec)
+	}
+
+	if _, err := u.GetHeartSignal(nil); err == nil {
+		t.Errorf("expected an error without params")
+	}
+	signal, err := u.GetHeartSignal(&HeartSignalQueryParam{SignalID: rec.ECG.SignalID})
+	if err != nil {
+		t.Fatalf("failed to get heart signal: %s", err)
+	}
+	if signal.Body.WearPosition != wearposition.LeftWrist || signal.Body.Duration() != 2*time.Second || signal.Body.SampleOffset(1) != 500*time.Millisecond {
+		t.Errorf("unexpected signal %+v", signal.Body)
+	}
+}
+
+func TestGetDevices(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		if r.URL.Query().Get("action") != "getdevice" || !strings.HasSuffix(r.URL.Path, "/v2/user") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		return `{"status":0,"body":{"devices":[
+			{"type":"Scale","model":"Body Cardio","model_id":6,"battery":"low","deviceid":"a1","fw":"1040","last_session_date":1540000000},
+			{"type":"Hub","model":"Unknown","battery":"high","deviceid":"b2"}]}}`
+	})
+
+	resp, err := u.GetDevices()
+	if err != nil {
+		t.Fatalf("failed to get devices: %s", err)
+	}
+	scale, hub := resp.Body.Devices[0], resp.Body.Devices[1]
+	if scale.TypeParsed == nil || *scale.TypeParsed != devtype.BodyScale || !scale.LowBattery() || scale.Firmware != "1040" {
+		t.Errorf("unexpected scale %+v", scale)
+	}
+	if scale.LastSessionDateParsed == nil || scale.LastSessionDateParsed.Unix() != 1540000000 || scale.FirstSessionDateParsed != nil {
+		t.Errorf("unexpected session dates %+v", scale)
+	}
+	if hub.TypeParsed != nil || hub.LowBattery() {
+		t.Errorf("unexpected hub %+v", hub)
+	}
+}
+
+func TestGetGoals(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		if r.URL.Query().Get("action") != "getgoals" || !strings.HasSuffix(r.URL.Path, "/v2/user") {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		return `{"status":0,"body":{"goals":{"steps":9000,"sleep":28800,"weight":{"value":70500,"unit":-3}}}}`
+	})
+
+	resp, err := u.GetGoals()
+	if err != nil {
+		t.Fatalf("failed to get goals: %s", err)
+	}
+	g := resp.Body.Goals
+	if g.Steps == nil || *g.Steps != 9000 {
+		t.Errorf("unexpected step goal %v", g.Steps)
+	}
+	if d, ok := g.SleepDuration(); !ok || d != 8*time.Hour {
+		t.Errorf("unexpected sleep goal %s", d)
+	}
+	if g.Weight == nil || math.Abs(g.Weight.Kgs()-70.5) > 1e-9 || g.Weight.Quantity().Unit != units.Kilogram {
+		t.Errorf("unexpected weight goal %+v", g.Weight)
+	}
+}
+
+func TestGetActivityMeasuresDataFields(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		q := r.URL.Query()
+		if q.Get("action") != "getactivity" || q.Get("offset") != "2" || q.Get("data_fields") != "totalcalories,hr_zone_0" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		return `{"status":0,"body":{"activities":[],"more":false,"offset":0}}`
+	})
+
+	offset := 2
+	p := ActivityMeasuresQueryParam{Offset: &offset, DataFields: []ActivityField{ActivityFieldTotalCalories, ActivityFieldHRZone0}}
+	if _, err := u.GetActivityMeasures(&p); err != nil {
+		t.Fatalf("failed to get activities: %s", err)
+	}
+}
+
+func TestGetActivityMeasuresUnknownTimeZone(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		return `{"status":0,"body":{"activity":[
+			{"date":"2018-07-01","timezone":"America/Los_Angeles","steps":100},
+			{"date":"2018-07-02","timezone":"Nowhere/Unknown","steps":200}]}}`
+	})
+
+	resp, err := u.GetActivityMeasures(nil)
+	if err != nil {
+		t.Fatalf("expected an unknown time zone not to fail the call: %s", err)
+	}
+	acts := resp.Body.Activities
+	if acts[0].ParsedDate == nil || CivilDate(*acts[0].ParsedDate) != "2018-07-01" || acts[0].ParsedDate.Location().String() != "America/Los_Angeles" {
+		t.Errorf("unexpected parsed date %v", acts[0].ParsedDate)
+	}
+	if acts[1].ParsedDate == nil || CivilDate(*acts[1].ParsedDate) != "2018-07-02" || acts[1].ParsedDate.Location() != time.UTC {
+		t.Errorf("expected the unknown time zone to fall back to UTC got %v", acts[1].ParsedDate)
+	}
+}
+
+func TestGetBodyMeasuresLastUpdate(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		if q := r.URL.Query(); q.Get("lastupdate") != "1500000000" || q.Get("enddate") != "" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		return `{"status":0,"body":{"timezone":"Europe/Paris","measuregrps":[{"grpid":1,"date":1500000000,"measures":[{"value":70,"type":1,"unit":0}]}]}}`
+	})
+
+	lastUpdate := time.Unix(1500000000, 0)
+	resp, err := u.GetBodyMeasures(&BodyMeasuresQueryParams{LastUpdate: &lastUpdate})
+	if err != nil {
+		t.Fatalf("failed to get body measures: %s", err)
+	}
+	if w := resp.ParseData().Weights; len(w) != 1 || w[0].Date.Location().String() != "Europe/Paris" {
+		t.Errorf("expected the weights in the time zone of the user got %+v", w)
+	}
+}
+
+func TestLastUpdateWithoutDateRange(t *testing.T) {
+	u := newMockUser(t, func(r *http.Request) string {
+		q := r.URL.Query()
+		if q.Get("lastupdate") != "1500000000" || q.Get("offset") != "3" || q.Get("startdateymd") != "" || q.Get("enddateymd") != "" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		return `{"status":0,"body":{}}`
+	})
+
+	lastUpdate := time.Unix(1500000000, 0)
+	offset := 3
+	if _, err := u.GetActivityMeasures(&ActivityMeasuresQueryParam{LasteUpdate: &lastUpdate, Offset: &offset}); err != nil {
+		t.Fatalf("failed to get activities: %s", err)
+	}
+	if _, err := u.GetSleepSummary(&SleepSummaryQueryParam{LastUpdate: &lastUpdate, Offset: &offset}); err != nil {
+		t.Fatalf("failed to get sleep summary: %s", err)
+	}
+}
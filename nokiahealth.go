@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jrmycanady/nokiahealth/enum/devtype"
 	"github.com/jrmycanady/nokiahealth/enum/status"
 	"golang.org/x/oauth2"
 	nokiaOauth2 "golang.org/x/oauth2/nokiahealth"
@@ -25,6 +26,10 @@ const (
 	getBodyMeasureURL             = "https://api.health.nokia.com/measure"
 	getSleepMeasureURL            = "https://api.health.nokia.com/v2/sleep"
 	getSleepSummaryURL            = "https://api.health.nokia.com/v2/sleep"
+	getDevicesURL                 = "https://api.health.nokia.com/v2/user"
+	getGoalsURL                   = "https://api.health.nokia.com/v2/user"
+	getHeartListURL               = "https://api.health.nokia.com/v2/heart"
+	getHeartSignalURL             = "https://api.health.nokia.com/v2/heart"
 	createNotficationURL          = "https://api.health.nokia.com/notify"
 	listNotificationsURL          = "https://api.health.nokia.com/notify"
 	getNotificationInformationURL = "https://api.health.nokia.com/notify"
@@ -216,6 +221,13 @@ func (u *User) GetIntradayActivityCtx(ctx context.Context, params *IntradayActiv
 		if params.EndDate != nil {
 			v.Add(GetFieldName(*params, "EndDate"), strconv.FormatInt(params.EndDate.Unix(), 10))
 		}
+		if len(params.DataFields) > 0 {
+			fields := make([]string, len(params.DataFields))
+			for i := range params.DataFields {
+				fields[i] = string(params.DataFields[i])
+			}
+			v.Add(GetFieldName(*params, "DataFields"), strings.Join(fields, ","))
+		}
 	}
 
 	// Sending request to the API.
@@ -253,6 +265,12 @@ func (u *User) GetIntradayActivityCtx(ctx context.Context, params *IntradayActiv
 		return intraDayActivityResponse, fmt.Errorf("api returned an error: %s", intraDayActivityResponse.Error)
 	}
 
+	// The API does not return the time zone of the samples so the location
+	// of the params is kept with them.
+	if params != nil {
+		intraDayActivityResponse.Location = params.Location
+	}
+
 	return intraDayActivityResponse, nil
 }
 
@@ -283,19 +301,31 @@ func (u *User) GetActivityMeasuresCtx(ctx context.Context, params *ActivityMeasu
 		// if params.Date != nil {
 		// 	v.Add(GetFieldName(*params, "Date"), params.Date.Format("2006-01-02"))
 		// }
+		// The date range defaults to the last day unless the changes since
+		// the last update are requested.
 		if params.StartDateYMD != nil {
 			v.Add(GetFieldName(*params, "StartDateYMD"), params.StartDateYMD.Format("2006-01-02"))
-		} else {
+		} else if params.LasteUpdate == nil {
 			v.Add(GetFieldName(*params, "StartDateYMD"), time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
 		}
 		if params.EndDateYMD != nil {
 			v.Add(GetFieldName(*params, "EndDateYMD"), params.EndDateYMD.Format("2006-01-02"))
-		} else {
+		} else if params.LasteUpdate == nil {
 			v.Add(GetFieldName(*params, "EndDateYMD"), time.Now().Format("2006-01-02"))
 		}
 		if params.LasteUpdate != nil {
 			v.Add(GetFieldName(*params, "LasteUpdate"), strconv.FormatInt(params.LasteUpdate.Unix(), 10))
 		}
+		if params.Offset != nil {
+			v.Add(GetFieldName(*params, "Offset"), strconv.Itoa(*params.Offset))
+		}
+		if len(params.DataFields) > 0 {
+			fields := make([]string, len(params.DataFields))
+			for i := range params.DataFields {
+				fields[i] = string(params.DataFields[i])
+			}
+			v.Add(GetFieldName(*params, "DataFields"), strings.Join(fields, ","))
+		}
 	} else {
 		params = &ActivityMeasuresQueryParam{}
 		v.Add(GetFieldName(*params, "StartDateYMD"), time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
@@ -339,37 +369,22 @@ func (u *User) GetActivityMeasuresCtx(ctx context.Context, params *ActivityMeasu
 		return activityMeasureResponse, fmt.Errorf("api returned an error: %s", activityMeasureResponse.Error)
 	}
 
-	// Parse date time if possible.
+	// Parse date time if possible. Dates that fail to parse are left nil.
+	if activityMeasureResponse.Body == nil {
+		return activityMeasureResponse, nil
+	}
 	if activityMeasureResponse.Body.Date != nil && activityMeasureResponse.Body.TimeZone != nil {
-		location, err := time.LoadLocation(*activityMeasureResponse.Body.TimeZone)
-		if err != nil {
-			return activityMeasureResponse, err
+		if t, err := ParseCivilDate(*activityMeasureResponse.Body.Date, *activityMeasureResponse.Body.TimeZone); err == nil {
+			activityMeasureResponse.Body.ParsedDate = &t
 		}
 
-		t, err := time.Parse("2006-01-02", *activityMeasureResponse.Body.Date)
-		if err != nil {
-			return activityMeasureResponse, err
-		}
-
-		t = t.In(location)
-		activityMeasureResponse.Body.ParsedDate = &t
-
 		activityMeasureResponse.Body.SingleValue = true
 	}
 
 	for aID := range activityMeasureResponse.Body.Activities {
-		location, err := time.LoadLocation(activityMeasureResponse.Body.Activities[aID].TimeZone)
-		if err != nil {
-			return activityMeasureResponse, err
+		if t, err := ParseCivilDate(activityMeasureResponse.Body.Activities[aID].Date, activityMeasureResponse.Body.Activities[aID].TimeZone); err == nil {
+			activityMeasureResponse.Body.Activities[aID].ParsedDate = &t
 		}
-
-		t, err := time.Parse("2006-01-02", activityMeasureResponse.Body.Activities[aID].Date)
-		if err != nil {
-			return activityMeasureResponse, err
-		}
-
-		t = t.In(location)
-		activityMeasureResponse.Body.Activities[aID].ParsedDate = &t
 	}
 
 	return activityMeasureResponse, nil
@@ -394,10 +409,6 @@ func (u *User) GetWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (
 	if err != nil {
 		return workoutResponse, fmt.Errorf("failed to obtain token: %s", err)
 	}
-	fmt.Printf("%v, %s, %s", t.Valid(), t.AccessToken, t.RefreshToken)
-	if err != nil {
-		return workoutResponse, fmt.Errorf("failed to obtain token: %s", err)
-	}
 	v.Add("access_token", t.AccessToken)
 	v.Add("action", "getworkouts")
 
@@ -408,6 +419,19 @@ func (u *User) GetWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (
 		if params.EndDateYMD != nil {
 			v.Add(GetFieldName(*params, "EndDateYMD"), params.EndDateYMD.Format("2006-01-02"))
 		}
+		if params.LastUpdate != nil {
+			v.Add(GetFieldName(*params, "LastUpdate"), strconv.FormatInt(params.LastUpdate.Unix(), 10))
+		}
+		if params.Offset != nil {
+			v.Add(GetFieldName(*params, "Offset"), strconv.Itoa(*params.Offset))
+		}
+		if len(params.DataFields) > 0 {
+			fields := make([]string, len(params.DataFields))
+			for i := range params.DataFields {
+				fields[i] = string(params.DataFields[i])
+			}
+			v.Add(GetFieldName(*params, "DataFields"), strings.Join(fields, ","))
+		}
 	}
 
 	// Sending request to the API.
@@ -431,7 +455,7 @@ func (u *User) GetWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (
 	// Processing API response.
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return workoutResponse, nil
+		return workoutResponse, err
 	}
 	if u.Client.SaveRawResponse {
 		workoutResponse.RawResponse = body
@@ -445,28 +469,20 @@ func (u *User) GetWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (
 		return workoutResponse, fmt.Errorf("api returned an error: %s", workoutResponse.Error)
 	}
 
-	// Parse dates if possible
+	// Parse dates if possible. Dates that fail to parse are left nil.
 	if workoutResponse.Body != nil {
 		for i := range workoutResponse.Body.Series {
-			d := time.Unix(workoutResponse.Body.Series[i].StartDate, 0)
-			workoutResponse.Body.Series[i].StartDateParsed = &d
+			w := &workoutResponse.Body.Series[i]
 
-			d = time.Unix(workoutResponse.Body.Series[i].EndDate, 0)
-			workoutResponse.Body.Series[i].EndDateParsed = &d
+			d := ParseUnix(w.StartDate, w.TimeZone)
+			w.StartDateParsed = &d
 
-			location, err := time.LoadLocation(workoutResponse.Body.Series[i].TimeZone)
-			if err != nil {
-				return workoutResponse, err
-			}
+			d = ParseUnix(w.EndDate, w.TimeZone)
+			w.EndDateParsed = &d
 
-			t, err := time.Parse("2006-01-02", workoutResponse.Body.Series[i].Date)
-			if err != nil {
-				return workoutResponse, err
+			if t, err := ParseCivilDate(w.Date, w.TimeZone); err == nil {
+				w.DateParsed = &t
 			}
-
-			t = t.In(location)
-
-			workoutResponse.Body.Series[i].DateParsed = &t
 		}
 	}
 
@@ -474,6 +490,74 @@ func (u *User) GetWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (
 
 }
 
+// GetAllWorkouts is the same as GetAllWorkoutsCtx but doesn't require a context to be provided.
+func (u *User) GetAllWorkouts(params *WorkoutsQueryParam) (WorkoutResponse, error) {
+	ctx, cancel := u.Client.getContext()
+	defer cancel()
+	return u.GetAllWorkoutsCtx(ctx, params)
+}
+
+// GetAllWorkoutsCtx retrieves the workouts like GetWorkoutsCtx but follows the
+// More and Offset of each response until every page has been retrieved. The
+// series of every page are combined in the returned response. The params are
+// not modified.
+//
+// If a page fails, or the API asks for more pages without advancing the
+// offset, the series retrieved so far are returned along with the error. More
+// is then left true and Offset is the offset of the page that was not
+// retrieved. The series of a page that did not advance are not returned so
+// resuming from Offset never retrieves them twice.
+func (u *User) GetAllWorkoutsCtx(ctx context.Context, params *WorkoutsQueryParam) (WorkoutResponse, error) {
+	p := WorkoutsQueryParam{}
+	if params != nil {
+		p = *params
+	}
+
+	var combined WorkoutResponse
+	var series []Workout
+	partial := func(offset int, err error) (WorkoutResponse, error) {
+		combined.Body.Series = series
+		combined.Body.More = true
+		combined.Body.Offset = offset
+		return combined, err
+	}
+
+	for {
+		workoutResponse, err := u.GetWorkoutsCtx(ctx, &p)
+		if combined.Body == nil {
+			// Nothing was retrieved yet so the first page is returned as is.
+			if err != nil || workoutResponse.Body == nil {
+				return workoutResponse, err
+			}
+			combined = workoutResponse
+		} else {
+			if err != nil {
+				return partial(*p.Offset, fmt.Errorf("failed to retrieve workouts at offset %d: %s", *p.Offset, err))
+			}
+			if workoutResponse.Body == nil {
+				return partial(*p.Offset, fmt.Errorf("api returned no workouts at offset %d", *p.Offset))
+			}
+		}
+
+		if !workoutResponse.Body.More {
+			combined.Body.Series = append(series, workoutResponse.Body.Series...)
+			combined.Body.More = false
+			combined.Body.Offset = workoutResponse.Body.Offset
+			return combined, nil
+		}
+		var prev int
+		if p.Offset != nil {
+			prev = *p.Offset
+		}
+		if workoutResponse.Body.Offset <= prev {
+			return partial(prev, fmt.Errorf("api did not advance past offset %d", prev))
+		}
+		series = append(series, workoutResponse.Body.Series...)
+		offset := workoutResponse.Body.Offset
+		p.Offset = &offset
+	}
+}
+
 // GetBodyMeasures is the same as GetBodyMeasuresCtx but doesn't require a context to be provided.
 func (u *User) GetBodyMeasures(params *BodyMeasuresQueryParams) (BodyMeasuresResp, error) {
 	ctx, cancel := u.Client.getContext()
@@ -503,7 +587,7 @@ func (u *User) GetBodyMeasuresCtx(ctx context.Context, params *BodyMeasuresQuery
 			v.Add(GetFieldName(*params, "EndDate"), strconv.FormatInt(params.EndDate.Unix(), 10))
 		}
 		if params.LastUpdate != nil {
-			v.Add(GetFieldName(*params, "LastUpdate"), strconv.FormatInt(params.EndDate.Unix(), 10))
+			v.Add(GetFieldName(*params, "LastUpdate"), strconv.FormatInt(params.LastUpdate.Unix(), 10))
 		}
 		if params.DevType != nil {
 			v.Add(GetFieldName(*params, "DevType"), strconv.Itoa(int(*params.DevType)))
@@ -512,7 +596,7 @@ func (u *User) GetBodyMeasuresCtx(ctx context.Context, params *BodyMeasuresQuery
 			v.Add(GetFieldName(*params, "MeasType"), strconv.Itoa(int(*params.MeasType)))
 		}
 		if params.Category != nil {
-			v.Add(GetFieldName(*params, "Category"), strconv.Itoa(*params.Category))
+			v.Add(GetFieldName(*params, "Category"), strconv.Itoa(int(*params.Category)))
 		}
 		if params.Limit != nil {
 			v.Add(GetFieldName(*params, "Limit"), strconv.Itoa(*params.Limit))
@@ -558,13 +642,298 @@ func (u *User) GetBodyMeasuresCtx(ctx context.Context, params *BodyMeasuresQuery
 	}
 
 	if params != nil && params.ParseResponse {
-		bodyMeasureResponse.ParsedResponse = bodyMeasureResponse.ParseData()
+		bodyMeasureResponse.ParsedResponse = bodyMeasureResponse.ParseData(params.Filters...)
 	}
 
 	return bodyMeasureResponse, nil
 
 }
 
+// GetDevices is the same as GetDevicesCtx but doesn't require a context to be provided.
+func (u *User) GetDevices() (DevicesResp, error) {
+	ctx, cancel := u.Client.getContext()
+	defer cancel()
+	return u.GetDevicesCtx(ctx)
+}
+
+// GetDevicesCtx retrieves the devices linked to the user such as scales, blood
+// pressure monitors, trackers and sleep monitors.
+func (u *User) GetDevicesCtx(ctx context.Context) (DevicesResp, error) {
+	devicesResponse := DevicesResp{}
+
+	// Building query params
+	v := url.Values{}
+	t, err := u.Token()
+	if err != nil {
+		return devicesResponse, fmt.Errorf("failed to obtain token: %s", err)
+	}
+	v.Add("access_token", t.AccessToken)
+	v.Add("action", "getdevice")
+
+	// Sending request to the API.
+	path := fmt.Sprintf("%s?%s", getDevicesURL, v.Encode())
+	if u.Client.IncludePath {
+		devicesResponse.Path = path
+	}
+
+	req, err := http.NewRequest("GET", path, nil)
+	req = req.WithContext(ctx)
+	if err != nil {
+		return devicesResponse, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return devicesResponse, err
+	}
+	defer resp.Body.Close()
+
+	// Processing API response.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return devicesResponse, err
+	}
+	if u.Client.SaveRawResponse {
+		devicesResponse.RawResponse = body
+	}
+
+	err = json.Unmarshal(body, &devicesResponse)
+	if err != nil {
+		return devicesResponse, err
+	}
+	if devicesResponse.Status != status.OperationWasSuccessful {
+		return devicesResponse, fmt.Errorf("api returned an error: %s", devicesResponse.Error)
+	}
+
+	// Parse types and dates
+	if devicesResponse.Body != nil {
+		for i := range devicesResponse.Body.Devices {
+			d := &devicesResponse.Body.Devices[i]
+			if dt, err := devtype.ParseDeviceType(d.Type); err == nil {
+				d.TypeParsed = &dt
+			}
+			if d.FirstSessionDate != 0 {
+				t := ParseUnix(d.FirstSessionDate, d.TimeZone)
+				d.FirstSessionDateParsed = &t
+			}
+			if d.LastSessionDate != 0 {
+				t := ParseUnix(d.LastSessionDate, d.TimeZone)
+				d.LastSessionDateParsed = &t
+			}
+		}
+	}
+
+	return devicesResponse, nil
+}
+
+// GetGoals is the same as GetGoalsCtx but doesn't require a context to be provided.
+func (u *User) GetGoals() (GoalsResp, error) {
+	ctx, cancel := u.Client.getContext()
+	defer cancel()
+	return u.GetGoalsCtx(ctx)
+}
+
+// GetGoalsCtx retrieves the step, sleep and weight goals set by the user.
+func (u *User) GetGoalsCtx(ctx context.Context) (GoalsResp, error) {
+	goalsResponse := GoalsResp{}
+
+	// Building query params
+	v := url.Values{}
+	t, err := u.Token()
+	if err != nil {
+		return goalsResponse, fmt.Errorf("failed to obtain token: %s", err)
+	}
+	v.Add("access_token", t.AccessToken)
+	v.Add("action", "getgoals")
+
+	// Sending request to the API.
+	path := fmt.Sprintf("%s?%s", getGoalsURL, v.Encode())
+	if u.Client.IncludePath {
+		goalsResponse.Path = path
+	}
+
+	req, err := http.NewRequest("GET", path, nil)
+	req = req.WithContext(ctx)
+	if err != nil {
+		return goalsResponse, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return goalsResponse, err
+	}
+	defer resp.Body.Close()
+
+	// Processing API response.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return goalsResponse, err
+	}
+	if u.Client.SaveRawResponse {
+		goalsResponse.RawResponse = body
+	}
+
+	err = json.Unmarshal(body, &goalsResponse)
+	if err != nil {
+		return goalsResponse, err
+	}
+	if goalsResponse.Status != status.OperationWasSuccessful {
+		return goalsResponse, fmt.Errorf("api returned an error: %s", goalsResponse.Error)
+	}
+
+	return goalsResponse, nil
+}
+
+// GetHeartList is the same as GetHeartListCtx but doesn't require a context to be provided.
+func (u *User) GetHeartList(params *HeartListQueryParam) (HeartListResp, error) {
+	ctx, cancel := u.Client.getContext()
+	defer cancel()
+	return u.GetHeartListCtx(ctx, params)
+}
+
+// GetHeartListCtx retrieves the heart recordings such as ECGs for the date range
+// provided by params. If params is nil the most recent recordings are returned.
+func (u *User) GetHeartListCtx(ctx context.Context, params *HeartListQueryParam) (HeartListResp, error) {
+	heartListResponse := HeartListResp{}
+
+	// Building query params
+	v := url.Values{}
+	t, err := u.Token()
+	if err != nil {
+		return heartListResponse, fmt.Errorf("failed to obtain token: %s", err)
+	}
+	v.Add("access_token", t.AccessToken)
+	v.Add("action", "list")
+
+	if params != nil {
+		if params.StartDate != nil {
+			v.Add(GetFieldName(*params, "StartDate"), strconv.FormatInt(params.StartDate.Unix(), 10))
+		}
+		if params.EndDate != nil {
+			v.Add(GetFieldName(*params, "EndDate"), strconv.FormatInt(params.EndDate.Unix(), 10))
+		}
+		if params.Offset != nil {
+			v.Add(GetFieldName(*params, "Offset"), strconv.Itoa(*params.Offset))
+		}
+	}
+
+	// Sending request to the API.
+	path := fmt.Sprintf("%s?%s", getHeartListURL, v.Encode())
+	if u.Client.IncludePath {
+		heartListResponse.Path = path
+	}
+
+	req, err := http.NewRequest("GET", path, nil)
+	req = req.WithContext(ctx)
+	if err != nil {
+		return heartListResponse, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return heartListResponse, err
+	}
+	defer resp.Body.Close()
+
+	// Processing API response.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return heartListResponse, err
+	}
+	if u.Client.SaveRawResponse {
+		heartListResponse.RawResponse = body
+	}
+
+	err = json.Unmarshal(body, &heartListResponse)
+	if err != nil {
+		return heartListResponse, err
+	}
+	if heartListResponse.Status != status.OperationWasSuccessful {
+		return heartListResponse, fmt.Errorf("api returned an error: %s", heartListResponse.Error)
+	}
+
+	// Parse dates
+	if heartListResponse.Body != nil {
+		for i := range heartListResponse.Body.Series {
+			t := ParseUnix(heartListResponse.Body.Series[i].Timestamp, heartListResponse.Body.Series[i].TimeZone)
+			heartListResponse.Body.Series[i].TimestampParsed = &t
+		}
+	}
+
+	return heartListResponse, nil
+}
+
+// GetHeartSignal is the same as GetHeartSignalCtx but doesn't require a context to be provided.
+func (u *User) GetHeartSignal(params *HeartSignalQueryParam) (HeartSignalResp, error) {
+	ctx, cancel := u.Client.getContext()
+	defer cancel()
+	return u.GetHeartSignalCtx(ctx, params)
+}
+
+// GetHeartSignalCtx retrieves the raw ECG signal of a heart recording. The
+// params are required as they identify the signal to retrieve.
+func (u *User) GetHeartSignalCtx(ctx context.Context, params *HeartSignalQueryParam) (HeartSignalResp, error) {
+	heartSignalResponse := HeartSignalResp{}
+
+	if params == nil {
+		return heartSignalResponse, fmt.Errorf("params are required to identify the signal")
+	}
+
+	// Building query params
+	v := url.Values{}
+	t, err := u.Token()
+	if err != nil {
+		return heartSignalResponse, fmt.Errorf("failed to obtain token: %s", err)
+	}
+	v.Add("access_token", t.AccessToken)
+	v.Add("action", "get")
+
+	v.Add(GetFieldName(*params, "SignalID"), strconv.Itoa(params.SignalID))
+	if params.WithFiltered != nil {
+		v.Add(GetFieldName(*params, "WithFiltered"), strconv.FormatBool(*params.WithFiltered))
+	}
+	if params.WithIntervals != nil {
+		v.Add(GetFieldName(*params, "WithIntervals"), strconv.FormatBool(*params.WithIntervals))
+	}
+
+	// Sending request to the API.
+	path := fmt.Sprintf("%s?%s", getHeartSignalURL, v.Encode())
+	if u.Client.IncludePath {
+		heartSignalResponse.Path = path
+	}
+
+	req, err := http.NewRequest("GET", path, nil)
+	req = req.WithContext(ctx)
+	if err != nil {
+		return heartSignalResponse, fmt.Errorf("failed to build request: %s", err)
+	}
+
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return heartSignalResponse, err
+	}
+	defer resp.Body.Close()
+
+	// Processing API response.
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return heartSignalResponse, err
+	}
+	if u.Client.SaveRawResponse {
+		heartSignalResponse.RawResponse = body
+	}
+
+	err = json.Unmarshal(body, &heartSignalResponse)
+	if err != nil {
+		return heartSignalResponse, err
+	}
+	if heartSignalResponse.Status != status.OperationWasSuccessful {
+		return heartSignalResponse, fmt.Errorf("api returned an error: %s", heartSignalResponse.Error)
+	}
+
+	return heartSignalResponse, nil
+}
+
 // GetSleepMeasures is the same as GetSleepMeasuresCtx but doesn't require a context to be provided.
 func (u *User) GetSleepMeasures(params *SleepMeasuresQueryParam) (SleepMeasuresResp, error) {
 	ctx, cancel := u.Client.getContext()
@@ -591,12 +960,19 @@ func (u *User) GetSleepMeasuresCtx(ctx context.Context, params *SleepMeasuresQue
 	// one with sensible defaults if needed.
 	if params == nil {
 		params = &SleepMeasuresQueryParam{}
-		params.StartDate = time.Now()
-		params.EndDate = time.Now().AddDate(0, 0, -1)
+		params.StartDate = time.Now().AddDate(0, 0, -1)
+		params.EndDate = time.Now()
 	}
 
 	v.Add(GetFieldName(*params, "StartDate"), strconv.FormatInt(params.StartDate.Unix(), 10))
 	v.Add(GetFieldName(*params, "EndDate"), strconv.FormatInt(params.EndDate.Unix(), 10))
+	if len(params.DataFields) > 0 {
+		fields := make([]string, len(params.DataFields))
+		for i := range params.DataFields {
+			fields[i] = string(params.DataFields[i])
+		}
+		v.Add(GetFieldName(*params, "DataFields"), strings.Join(fields, ","))
+	}
 
 	// Sending request to the API.
 	path := fmt.Sprintf("%s?%s", getSleepMeasureURL, v.Encode())
@@ -633,13 +1009,18 @@ func (u *User) GetSleepMeasuresCtx(ctx context.Context, params *SleepMeasuresQue
 		return sleepMeasureRepsonse, fmt.Errorf("api returned an error: %s", sleepMeasureRepsonse.Error)
 	}
 
-	// Parse dates
+	// Parse dates. The API does not return the time zone of the measures so
+	// the location of the params is used.
+	location := params.Location
+	if location == nil {
+		location = time.Local
+	}
 	if sleepMeasureRepsonse.Body != nil {
 		for i := range sleepMeasureRepsonse.Body.Series {
-			t := time.Unix(sleepMeasureRepsonse.Body.Series[i].StartDate, 0)
+			t := time.Unix(sleepMeasureRepsonse.Body.Series[i].StartDate, 0).In(location)
 			sleepMeasureRepsonse.Body.Series[i].StartDateParsed = &t
 
-			t = time.Unix(sleepMeasureRepsonse.Body.Series[i].EndDate, 0)
+			t = time.Unix(sleepMeasureRepsonse.Body.Series[i].EndDate, 0).In(location)
 			sleepMeasureRepsonse.Body.Series[i].EndDateParsed = &t
 		}
 	}
@@ -672,15 +1053,32 @@ func (u *User) GetSleepSummaryCtx(ctx context.Context, params *SleepSummaryQuery
 	// one with sensible defaults if needed.
 	if params == nil {
 		params = &SleepSummaryQueryParam{}
-		t1 := time.Now()
-		t2 := time.Now().AddDate(0, 0, -1)
+		t1 := time.Now().AddDate(0, 0, -1)
+		t2 := time.Now()
 		params.StartDateYMD = &t1
 		params.EndDateYMD = &t2
 	}
 
 	// Although the API currently says the type is a UNIX time stamp the reality is it's a date string.
-	v.Add(GetFieldName(*params, "StartDateYMD"), params.StartDateYMD.Format("2006-01-02"))
-	v.Add(GetFieldName(*params, "EndDateYMD"), params.EndDateYMD.Format("2006-01-02"))
+	if params.StartDateYMD != nil {
+		v.Add(GetFieldName(*params, "StartDateYMD"), params.StartDateYMD.Format("2006-01-02"))
+	}
+	if params.EndDateYMD != nil {
+		v.Add(GetFieldName(*params, "EndDateYMD"), params.EndDateYMD.Format("2006-01-02"))
+	}
+	if params.LastUpdate != nil {
+		v.Add(GetFieldName(*params, "LastUpdate"), strconv.FormatInt(params.LastUpdate.Unix(), 10))
+	}
+	if params.Offset != nil {
+		v.Add(GetFieldName(*params, "Offset"), strconv.Itoa(*params.Offset))
+	}
+	if len(params.DataFields) > 0 {
+		fields := make([]string, len(params.DataFields))
+		for i := range params.DataFields {
+			fields[i] = string(params.DataFields[i])
+		}
+		v.Add(GetFieldName(*params, "DataFields"), strings.Join(fields, ","))
+	}
 
 	// Sending request to the API.
 	path := fmt.Sprintf("%s?%s", getSleepSummaryURL, v.Encode())
@@ -721,25 +1119,18 @@ func (u *User) GetSleepSummaryCtx(ctx context.Context, params *SleepSummaryQuery
 	if sleepSummaryResponse.Body != nil {
 		for i := range sleepSummaryResponse.Body.Series {
 
+			s := &sleepSummaryResponse.Body.Series[i]
+
 			// Parse the normal UNIX time stamps.
-			startDate := time.Unix(sleepSummaryResponse.Body.Series[i].StartDate, 0)
-			endDate := time.Unix(sleepSummaryResponse.Body.Series[i].EndDate, 0)
-			sleepSummaryResponse.Body.Series[i].StartDateParsed = &startDate
-			sleepSummaryResponse.Body.Series[i].EndDateParsed = &endDate
+			startDate := ParseUnix(s.StartDate, s.TimeZone)
+			endDate := ParseUnix(s.EndDate, s.TimeZone)
+			s.StartDateParsed = &startDate
+			s.EndDateParsed = &endDate
 
 			// Parse the goofy YYYY-MM-DD plus location date.
-			location, err := time.LoadLocation(sleepSummaryResponse.Body.Series[i].TimeZone)
-			if err != nil {
-				return sleepSummaryResponse, err
+			if t, err := ParseCivilDate(s.Date, s.TimeZone); err == nil {
+				s.DateParsed = &t
 			}
-
-			t, err := time.Parse("2006-01-02", sleepSummaryResponse.Body.Series[i].Date)
-			if err != nil {
-				return sleepSummaryResponse, err
-			}
-
-			t = t.In(location)
-			sleepSummaryResponse.Body.Series[i].DateParsed = &t
 		}
 	}
 
@@ -0,0 +1,171 @@
+package nokiahealth
+
+import (
+	"github.com/jrmycanady/nokiahealth/units"
+)
+
+// Quantity methods return the parsed values along with their unit so they
+// can be converted and rendered with the units package, i.e.
+//	w.Quantity().In(units.UK).String() // 11 st 6 lb
+
+// Quantity returns the Kgs value of the Weight as a units.Quantity.
+func (m Weight) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram).WithKind(units.BodyWeight)
+}
+
+// Quantity returns the Meters value of the Height as a units.Quantity.
+func (m Height) Quantity() units.Quantity {
+	return units.New(m.Meters, units.Meter).WithKind(units.BodyHeight)
+}
+
+// Quantity returns the Kgs value of the FatFreeMass as a units.Quantity.
+func (m FatFreeMass) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram)
+}
+
+// Quantity returns the Kgs value of the FatMassWeight as a units.Quantity.
+func (m FatMassWeight) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram)
+}
+
+// Quantity returns the Ratio value of the FatRatio as a units.Quantity.
+func (m FatRatio) Quantity() units.Quantity {
+	return units.New(m.Ratio, units.Percent)
+}
+
+// Quantity returns the MmHg value of the DiastolicBloodPressure as a units.Quantity.
+func (m DiastolicBloodPressure) Quantity() units.Quantity {
+	return units.New(m.MmHg, units.MillimeterOfMercury)
+}
+
+// Quantity returns the MmHg value of the SystolicBloodPressure as a units.Quantity.
+func (m SystolicBloodPressure) Quantity() units.Quantity {
+	return units.New(m.MmHg, units.MillimeterOfMercury)
+}
+
+// Quantity returns the BPM value of the HeartPulse as a units.Quantity.
+func (m HeartPulse) Quantity() units.Quantity {
+	return units.New(m.BPM, units.BeatsPerMinute)
+}
+
+// Quantity returns the Celcius value of the Temperature as a units.Quantity.
+func (m Temperature) Quantity() units.Quantity {
+	return units.New(m.Celcius, units.Celsius)
+}
+
+// Quantity returns the Percentage value of the SP02Percent as a units.Quantity.
+func (m SP02Percent) Quantity() units.Quantity {
+	return units.New(m.Percentage, units.Percent)
+}
+
+// Quantity returns the Celcius value of the BodyTemperature as a units.Quantity.
+func (m BodyTemperature) Quantity() units.Quantity {
+	return units.New(m.Celcius, units.Celsius)
+}
+
+// Quantity returns the Celcius value of the SkinTemperature as a units.Quantity.
+func (m SkinTemperature) Quantity() units.Quantity {
+	return units.New(m.Celcius, units.Celsius)
+}
+
+// Quantity returns the Mass value of the MuscleMass as a units.Quantity.
+func (m MuscleMass) Quantity() units.Quantity {
+	return units.New(m.Mass, units.Kilogram)
+}
+
+// Quantity returns the Hydration value of the Hydration as a units.Quantity.
+func (m Hydration) Quantity() units.Quantity {
+	return units.New(m.Hydration, units.Kilogram)
+}
+
+// Quantity returns the Mass value of the BoneMass as a units.Quantity.
+func (m BoneMass) Quantity() units.Quantity {
+	return units.New(m.Mass, units.Kilogram)
+}
+
+// Quantity returns the Velocity value of the PulseWaveVelocity as a units.Quantity.
+func (m PulseWaveVelocity) Quantity() units.Quantity {
+	return units.New(m.Velocity, units.MeterPerSecond)
+}
+
+// Quantity returns the MlPerMinKgs value of the VO2Max as a units.Quantity.
+func (m VO2Max) Quantity() units.Quantity {
+	return units.New(m.MlPerMinKgs, units.MilliliterPerMinutePerKilogram)
+}
+
+// Quantity returns the Milliseconds value of the ECGInterval as a units.Quantity.
+func (m ECGInterval) Quantity() units.Quantity {
+	return units.New(m.Milliseconds, units.Millisecond)
+}
+
+// Quantity returns the Years value of the VascularAge as a units.Quantity.
+func (m VascularAge) Quantity() units.Quantity {
+	return units.New(m.Years, units.Year)
+}
+
+// Quantity returns the Score value of the NerveHealthScore as a units.Quantity.
+func (m NerveHealthScore) Quantity() units.Quantity {
+	return units.New(m.Score, units.None)
+}
+
+// Quantity returns the Kgs value of the ExtracellularWater as a units.Quantity.
+func (m ExtracellularWater) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram)
+}
+
+// Quantity returns the Kgs value of the IntracellularWater as a units.Quantity.
+func (m IntracellularWater) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram)
+}
+
+// Quantity returns the Index value of the VisceralFat as a units.Quantity.
+func (m VisceralFat) Quantity() units.Quantity {
+	return units.New(m.Index, units.None)
+}
+
+// Quantity returns the Kgs value of the SegmentMass as a units.Quantity.
+func (m SegmentMass) Quantity() units.Quantity {
+	return units.New(m.Kgs, units.Kilogram)
+}
+
+// Quantity returns the Activity value of the ElectrodermalActivity as a units.Quantity.
+func (m ElectrodermalActivity) Quantity() units.Quantity {
+	return units.New(m.Activity, units.None)
+}
+
+// Quantity returns the Kcal value of the BasalMetabolicRate as a units.Quantity.
+func (m BasalMetabolicRate) Quantity() units.Quantity {
+	return units.New(m.Kcal, units.Kilocalorie)
+}
+
+// Quantity returns the Years value of the MetabolicAge as a units.Quantity.
+func (m MetabolicAge) Quantity() units.Quantity {
+	return units.New(m.Years, units.Year)
+}
+
+// Quantity returns the MicroSiemens value of the ElectrochemicalSkinConductance as a units.Quantity.
+func (m ElectrochemicalSkinConductance) Quantity() units.Quantity {
+	return units.New(m.MicroSiemens, units.Microsiemens)
+}
+
+// Quantity returns the measure value as a units.Quantity using the unit of
+// the measure type.
+func (m OtherMeasure) Quantity() units.Quantity {
+	return units.New(m.Value, units.ForMeasType(m.Type)).WithKind(units.KindOfMeasType(m.Type))
+}
+
+// DistanceQuantity returns the distance of the activity in kilometers. The
+// API reports the distance in meters.
+func (a Activity) DistanceQuantity() units.Quantity {
+	return units.New(a.Distance/1000, units.Kilometer)
+}
+
+// ElevationQuantity returns the elevation climbed during the activity.
+func (a Activity) ElevationQuantity() units.Quantity {
+	return units.New(a.Elevation, units.Meter)
+}
+
+// Quantity returns the weight goal as a units.Quantity.
+func (w GoalWeight) Quantity() units.Quantity {
+	return units.New(w.Kgs(), units.Kilogram).WithKind(units.BodyWeight)
+}
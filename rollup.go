@@ -0,0 +1,177 @@
+package nokiahealth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth/enum/category"
+)
+
+// DailyRollup combines the data of a single calendar day. Each record is
+// attributed to the day using its own time zone so days stay correct when the
+// user travels. Sleep is attributed to the day the user woke up.
+type DailyRollup struct {
+	// Date is the day in the YYYY-MM-DD format used by the API.
+	Date string
+	// TimeZone is the time zone of the activity of the day or of the first
+	// record of the day if there is no activity.
+	TimeZone string
+
+	Activity *Activity
+	Workouts []Workout
+	Sleep    []SleepSummary
+	Weights  []Weight
+
+	// WorkoutDuration is the effective duration of the workouts, falling back
+	// to the time between their start and end.
+	WorkoutDuration time.Duration
+	// SleepDuration is the light, deep and REM sleep of the sleep summaries.
+	SleepDuration time.Duration
+}
+
+// Steps returns the steps of the day or zero if there is no activity.
+func (d DailyRollup) Steps() float64 {
+	if d.Activity == nil {
+		return 0
+	}
+	return d.Activity.Steps
+}
+
+// Weight returns the last weight of the day. The second return value is false
+// if the user did not weigh in.
+func (d DailyRollup) Weight() (Weight, bool) {
+	if len(d.Weights) == 0 {
+		return Weight{}, false
+	}
+	return d.Weights[len(d.Weights)-1], true
+}
+
+// DailyRollupBuilder builds daily rollups from responses of any type. The
+// responses may overlap. Records found more than once are only included once,
+// with the last activity of a day taking precedence.
+type DailyRollupBuilder struct {
+	days     map[string]*DailyRollup
+	workouts map[int]bool
+	sleep    map[int64]bool
+	groups   map[int]bool
+}
+
+// NewDailyRollupBuilder returns an empty builder.
+func NewDailyRollupBuilder() *DailyRollupBuilder {
+	return &DailyRollupBuilder{
+		days:     map[string]*DailyRollup{},
+		workouts: map[int]bool{},
+		sleep:    map[int64]bool{},
+		groups:   map[int]bool{},
+	}
+}
+
+// day returns the rollup of the date creating it if needed.
+func (b *DailyRollupBuilder) day(date string, tz string) *DailyRollup {
+	d, ok := b.days[date]
+	if !ok {
+		d = &DailyRollup{Date: date, TimeZone: tz}
+		b.days[date] = d
+	}
+	return d
+}
+
+// AddBodyMeasures adds the weights of the response matching the filters. If
+// no filters are provided and the response was already parsed, the parsed
+// weights are used so the filters of the query apply. Objectives set by the
+// user are never added as they are not weigh-ins.
+func (b *DailyRollupBuilder) AddBodyMeasures(resp BodyMeasuresResp, filters ...MeasureGroupFilter) *DailyRollupBuilder {
+	parsed := resp.ParsedResponse
+	if parsed == nil || len(filters) > 0 {
+		parsed = resp.ParseData(filters...)
+	}
+	if parsed == nil {
+		return b
+	}
+
+	for _, w := range parsed.Weights {
+		if w.Category == category.UserObjective || b.groups[w.GrpID] {
+			continue
+		}
+		b.groups[w.GrpID] = true
+
+		d := b.day(CivilDate(w.Date), w.Date.Location().String())
+		d.Weights = append(d.Weights, w)
+	}
+	return b
+}
+
+// AddActivities adds the activities of the response.
+func (b *DailyRollupBuilder) AddActivities(resp ActivitiesMeasuresResp) *DailyRollupBuilder {
+	for _, a := range resp.Activities() {
+		if a.Date == "" {
+			continue
+		}
+		a := a
+		d := b.day(a.Date, a.TimeZone)
+		d.Activity = &a
+		d.TimeZone = a.TimeZone
+	}
+	return b
+}
+
+// AddWorkouts adds the workouts of the response on the day they started.
+func (b *DailyRollupBuilder) AddWorkouts(resp WorkoutResponse) *DailyRollupBuilder {
+	if resp.Body == nil {
+		return b
+	}
+	for _, w := range resp.Body.Series {
+		if b.workouts[w.ID] {
+			continue
+		}
+		b.workouts[w.ID] = true
+
+		d := b.day(CivilDate(ParseUnix(w.StartDate, w.TimeZone)), w.TimeZone)
+		d.Workouts = append(d.Workouts, w)
+		switch {
+		case w.Data.EffectiveDuration != nil:
+			d.WorkoutDuration += time.Duration(*w.Data.EffectiveDuration * float64(time.Second))
+		case w.EndDate > w.StartDate:
+			d.WorkoutDuration += time.Duration(w.EndDate-w.StartDate) * time.Second
+		}
+	}
+	return b
+}
+
+// AddSleepSummaries adds the sleep summaries of the response on the day the
+// user woke up.
+func (b *DailyRollupBuilder) AddSleepSummaries(resp SleepSummaryResp) *DailyRollupBuilder {
+	if resp.Body == nil {
+		return b
+	}
+	for _, s := range resp.Body.Series {
+		if b.sleep[s.ID] {
+			continue
+		}
+		b.sleep[s.ID] = true
+
+		d := b.day(CivilDate(ParseUnix(s.EndDate, s.TimeZone)), s.TimeZone)
+		d.Sleep = append(d.Sleep, s)
+		for _, v := range []*int{s.Data.LightSleepDuration, s.Data.DeepSleepDuration, s.Data.REMSleepDuration} {
+			if v != nil {
+				d.SleepDuration += time.Duration(*v) * time.Second
+			}
+		}
+	}
+	return b
+}
+
+// Build returns the rollups ordered by date. Only days with at least one
+// record are included. Records within a day are ordered by time.
+func (b *DailyRollupBuilder) Build() []DailyRollup {
+	rollups := make([]DailyRollup, 0, len(b.days))
+	for _, d := range b.days {
+		r := *d
+		sort.SliceStable(r.Weights, func(i, j int) bool { return r.Weights[i].Date.Before(r.Weights[j].Date) })
+		sort.SliceStable(r.Workouts, func(i, j int) bool { return r.Workouts[i].StartDate < r.Workouts[j].StartDate })
+		sort.SliceStable(r.Sleep, func(i, j int) bool { return r.Sleep[i].EndDate < r.Sleep[j].EndDate })
+		rollups = append(rollups, r)
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].Date < rollups[j].Date })
+	return rollups
+}
@@ -0,0 +1,102 @@
+package nokiahealth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+	"github.com/jrmycanady/nokiahealth/enum/category"
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+)
+
+func TestDailyRollup(t *testing.T) {
+	paris, _ := LoadLocation("Europe/Paris")
+	newYork, _ := LoadLocation("America/New_York")
+	tokyo, _ := LoadLocation("Asia/Tokyo")
+
+	light, deep := 18000, 7200
+	sleep := SleepSummaryResp{Body: &SleepSummaryBody{Series: []SleepSummary{{
+		ID:        1,
+		StartDate: time.Date(2018, 7, 1, 23, 0, 0, 0, paris).Unix(),
+		EndDate:   time.Date(2018, 7, 2, 7, 0, 0, 0, paris).Unix(),
+		TimeZone:  "Europe/Paris",
+		Data:      SleepSummaryData{LightSleepDuration: &light, DeepSleepDuration: &deep},
+	}}}}
+
+	// A late workout whose UTC time falls on the next day.
+	start := time.Date(2018, 7, 1, 22, 0, 0, 0, newYork)
+	workouts := WorkoutResponse{Body: &WorkoutRespBody{Series: []Workout{{
+		ID:        5,
+		StartDate: start.Unix(),
+		EndDate:   start.Add(45 * time.Minute).Unix(),
+		TimeZone:  "America/New_York",
+	}}}}
+
+	weighIn := time.Date(2018, 7, 2, 8, 0, 0, 0, tokyo)
+	body := BodyMeasuresResp{Body: &BodyMeasureRespBody{
+		Timezone: "Asia/Tokyo",
+		MeasureGrps: []BodyMeasureGroupResp{
+			{GrpID: 9, Date: weighIn.Unix(), Measures: []BodyMeasuresMeasure{{Value: 71, Type: meastype.Weight}}},
+		},
+	}}
+
+	activities := ActivitiesMeasuresResp{Body: &ActivitiesMeasuresRespBody{Activities: []Activity{
+		{Date: "2018-07-01", TimeZone: "America/New_York", Steps: 9000},
+		{Date: "2018-07-02", TimeZone: "Asia/Tokyo", Steps: 4000},
+	}}}
+
+	days := NewDailyRollupBuilder().
+		AddSleepSummaries(sleep).
+		AddSleepSummaries(sleep).
+		AddWorkouts(workouts).
+		AddBodyMeasures(body).
+		AddActivities(activities).
+		Build()
+
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days got %+v", days)
+	}
+	first, second := days[0], days[1]
+
+	if first.Date != "2018-07-01" || first.Steps() != 9000 || len(first.Workouts) != 1 || first.WorkoutDuration != 45*time.Minute {
+		t.Errorf("unexpected first day %+v", first)
+	}
+	if len(first.Sleep) != 0 {
+		t.Errorf("expected sleep to be attributed to the wake date")
+	}
+
+	if second.Date != "2018-07-02" || second.TimeZone != "Asia/Tokyo" || second.Steps() != 4000 {
+		t.Errorf("unexpected second day %+v", second)
+	}
+	if len(second.Sleep) != 1 || second.SleepDuration != 7*time.Hour {
+		t.Errorf("expected a single night of 7 hours got %d nights of %s", len(second.Sleep), second.SleepDuration)
+	}
+	if w, ok := second.Weight(); !ok || w.Kgs != 71 {
+		t.Errorf("unexpected weight %+v", w)
+	}
+}
+
+func TestDailyRollupWeights(t *testing.T) {
+	day := time.Date(2018, 7, 2, 8, 0, 0, 0, time.UTC).Unix()
+	body := BodyMeasuresResp{Body: &BodyMeasureRespBody{
+		Timezone: "UTC",
+		MeasureGrps: []BodyMeasureGroupResp{
+			{GrpID: 1, Date: day, Category: category.RealMeasurement, Measures: []BodyMeasuresMeasure{{Value: 70, Type: meastype.Weight}}},
+			{GrpID: 2, Date: day + 60, Category: category.UserObjective, Measures: []BodyMeasuresMeasure{{Value: 65, Type: meastype.Weight}}},
+			{GrpID: 3, Date: day + 120, Category: category.RealMeasurement, Attrib: attrib.ManualUserEntry, Measures: []BodyMeasuresMeasure{{Value: 72, Type: meastype.Weight}}},
+		},
+	}}
+
+	// Objectives are not weigh-ins.
+	days := NewDailyRollupBuilder().AddBodyMeasures(body).Build()
+	if len(days) != 1 || len(days[0].Weights) != 2 || days[0].Weights[0].GrpID != 1 || days[0].Weights[1].GrpID != 3 {
+		t.Fatalf("expected the objective to be ignored got %+v", days)
+	}
+
+	// The filters of the query apply when the response was parsed.
+	body.ParsedResponse = body.ParseData(ExcludeManual())
+	days = NewDailyRollupBuilder().AddBodyMeasures(body).Build()
+	if len(days) != 1 || len(days[0].Weights) != 1 || days[0].Weights[0].GrpID != 1 {
+		t.Errorf("expected the parsed response to be used got %+v", days)
+	}
+}
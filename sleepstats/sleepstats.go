@@ -0,0 +1,206 @@
+// Package sleepstats computes insight from the sleep summaries returned by the
+// Nokia Health API such as sleep efficiency, bedtime consistency and sleep
+// debt.
+package sleepstats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// DefaultTarget is the sleep target used when none is provided.
+const DefaultTarget = 8 * time.Hour
+
+// TargetFromGoals returns the sleep goal set by the user, as returned by
+// User.GetGoals, or DefaultTarget if there is none.
+func TargetFromGoals(g nokiahealth.UserGoals) time.Duration {
+	if d, ok := g.SleepDuration(); ok && d > 0 {
+		return d
+	}
+	return DefaultTarget
+}
+
+// Night is the analysis of a single sleep summary.
+type Night struct {
+	ID int64
+	// Start and End are the time the user went to bed and got up in the
+	// time zone of the summary.
+	Start time.Time
+	End   time.Time
+
+	TimeInBed  time.Duration
+	TotalSleep time.Duration
+	Light      time.Duration
+	Deep       time.Duration
+	REM        time.Duration
+	Awake      time.Duration
+	// Latency is the time it took to fall asleep.
+	Latency     time.Duration
+	WakeUpCount int
+
+	// Efficiency is the ratio of total sleep to time in bed between 0 and 1.
+	Efficiency   float64
+	LightPercent float64
+	DeepPercent  float64
+	REMPercent   float64
+}
+
+// seconds converts the API durations to a time.Duration. Missing durations
+// are treated as zero.
+func seconds(s *int) time.Duration {
+	if s == nil {
+		return 0
+	}
+	return time.Duration(*s) * time.Second
+}
+
+// NewNight analyzes a single sleep summary.
+func NewNight(s nokiahealth.SleepSummary) Night {
+	n := Night{
+		ID:      s.ID,
+		Start:   nokiahealth.ParseUnix(s.StartDate, s.TimeZone),
+		End:     nokiahealth.ParseUnix(s.EndDate, s.TimeZone),
+		Light:   seconds(s.Data.LightSleepDuration),
+		Deep:    seconds(s.Data.DeepSleepDuration),
+		REM:     seconds(s.Data.REMSleepDuration),
+		Awake:   seconds(s.Data.WakeUpDuration),
+		Latency: seconds(s.Data.DurationToSleep),
+	}
+	if s.Data.WakeUpCount != nil {
+		n.WakeUpCount = *s.Data.WakeUpCount
+	}
+
+	n.TimeInBed = n.End.Sub(n.Start)
+	n.TotalSleep = n.Light + n.Deep + n.REM
+
+	if n.TimeInBed > 0 {
+		n.Efficiency = math.Min(1, float64(n.TotalSleep)/float64(n.TimeInBed))
+	}
+	if n.TotalSleep > 0 {
+		n.LightPercent = float64(n.Light) / float64(n.TotalSleep) * 100
+		n.DeepPercent = float64(n.Deep) / float64(n.TotalSleep) * 100
+		n.REMPercent = float64(n.REM) / float64(n.TotalSleep) * 100
+	}
+
+	return n
+}
+
+// Nights analyzes every summary of the response sorted by start date.
+func Nights(resp nokiahealth.SleepSummaryResp) []Night {
+	var nights []Night
+	if resp.Body == nil {
+		return nights
+	}
+	for _, s := range resp.Body.Series {
+		nights = append(nights, NewNight(s))
+	}
+	sort.Slice(nights, func(i, j int) bool {
+		return nights[i].Start.Before(nights[j].Start)
+	})
+	return nights
+}
+
+// Debt is the cumulative sleep debt after a night.
+type Debt struct {
+	Date time.Time
+	// Balance is the difference between the target and the sleep of the
+	// night. Positive values mean the night was short of the target.
+	Balance time.Duration
+	// Cumulative is the debt accumulated so far. Surplus sleep repays the
+	// debt but it never goes below zero.
+	Cumulative time.Duration
+}
+
+// Report summarizes the sleep over a set of nights.
+type Report struct {
+	Nights []Night
+	Target time.Duration
+
+	AverageTotalSleep time.Duration
+	AverageEfficiency float64
+	AverageLatency    time.Duration
+
+	// BedtimeConsistency and WakeTimeConsistency are the standard deviation
+	// of the clock time the user went to bed and got up. Lower values mean a
+	// more regular schedule.
+	BedtimeConsistency  time.Duration
+	WakeTimeConsistency time.Duration
+
+	Debt      []Debt
+	TotalDebt time.Duration
+}
+
+// Analyze builds a report from the sleep summary response. The target is the
+// amount of sleep wanted per night and defaults to DefaultTarget.
+func Analyze(resp nokiahealth.SleepSummaryResp, target time.Duration) Report {
+	return AnalyzeNights(Nights(resp), target)
+}
+
+// AnalyzeNights builds a report from nights that have already been analyzed.
+// The nights must be sorted by start date.
+func AnalyzeNights(nights []Night, target time.Duration) Report {
+	if target <= 0 {
+		target = DefaultTarget
+	}
+	r := Report{Nights: nights, Target: target}
+	if len(nights) == 0 {
+		return r
+	}
+
+	var totalSleep, totalLatency time.Duration
+	var totalEfficiency float64
+	var bedtimes, waketimes []time.Time
+	for _, n := range nights {
+		totalSleep += n.TotalSleep
+		totalLatency += n.Latency
+		totalEfficiency += n.Efficiency
+		bedtimes = append(bedtimes, n.Start)
+		waketimes = append(waketimes, n.End)
+
+		balance := target - n.TotalSleep
+		r.TotalDebt += balance
+		if r.TotalDebt < 0 {
+			r.TotalDebt = 0
+		}
+		r.Debt = append(r.Debt, Debt{Date: n.End, Balance: balance, Cumulative: r.TotalDebt})
+	}
+
+	count := time.Duration(len(nights))
+	r.AverageTotalSleep = totalSleep / count
+	r.AverageLatency = totalLatency / count
+	r.AverageEfficiency = totalEfficiency / float64(len(nights))
+	r.BedtimeConsistency = ClockDeviation(bedtimes)
+	r.WakeTimeConsistency = ClockDeviation(waketimes)
+
+	return r
+}
+
+// ClockDeviation returns the circular standard deviation of the clock time
+// of the times provided. The clock time is read in the location of each time
+// and treated as a point on a 24 hour circle so 23:30 and 00:30 are an hour
+// apart.
+func ClockDeviation(times []time.Time) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+
+	const day = 24 * 60 * 60
+	var sumSin, sumCos float64
+	for _, t := range times {
+		secs := t.Hour()*3600 + t.Minute()*60 + t.Second()
+		angle := 2 * math.Pi * float64(secs) / day
+		sumSin += math.Sin(angle)
+		sumCos += math.Cos(angle)
+	}
+
+	n := float64(len(times))
+	r := math.Hypot(sumSin/n, sumCos/n)
+	if r >= 1 {
+		return 0
+	}
+	std := math.Sqrt(-2 * math.Log(r))
+	return time.Duration(std / (2 * math.Pi) * day * float64(time.Second))
+}
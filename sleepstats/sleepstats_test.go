@@ -0,0 +1,77 @@
+package sleepstats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+func summary(id int64, start time.Time, inBed time.Duration, light, deep, rem int) nokiahealth.SleepSummary {
+	latency := 600
+	return nokiahealth.SleepSummary{
+		ID:        id,
+		StartDate: start.Unix(),
+		EndDate:   start.Add(inBed).Unix(),
+		TimeZone:  "UTC",
+		Data: nokiahealth.SleepSummaryData{
+			LightSleepDuration: &light,
+			DeepSleepDuration:  &deep,
+			REMSleepDuration:   &rem,
+			DurationToSleep:    &latency,
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	d := time.Date(2018, 5, 1, 23, 0, 0, 0, time.UTC)
+	resp := nokiahealth.SleepSummaryResp{
+		Body: &nokiahealth.SleepSummaryBody{
+			Series: []nokiahealth.SleepSummary{
+				summary(2, d.Add(24*time.Hour+time.Hour), 8*time.Hour, 4*3600, 2*3600, 3600),
+				summary(1, d, 8*time.Hour, 3*3600, 2*3600, 3600),
+			},
+		},
+	}
+
+	r := Analyze(resp, 8*time.Hour)
+
+	if len(r.Nights) != 2 || r.Nights[0].ID != 1 {
+		t.Fatalf("expected nights sorted by start got %+v", r.Nights)
+	}
+	n := r.Nights[0]
+	if n.TotalSleep != 6*time.Hour || n.Efficiency != 0.75 || n.Latency != 10*time.Minute {
+		t.Errorf("unexpected night %+v", n)
+	}
+	if math.Abs(n.REMPercent-100.0/6) > 1e-9 {
+		t.Errorf("expected rem percent %f got %f", 100.0/6, n.REMPercent)
+	}
+
+	if r.Debt[0].Cumulative != 2*time.Hour || r.Debt[1].Cumulative != 3*time.Hour || r.TotalDebt != 3*time.Hour {
+		t.Errorf("unexpected debt %+v", r.Debt)
+	}
+
+	// Bedtimes an hour apart either side of midnight.
+	if r.BedtimeConsistency < 29*time.Minute || r.BedtimeConsistency > 31*time.Minute {
+		t.Errorf("expected bedtime deviation of about 30 minutes got %s", r.BedtimeConsistency)
+	}
+}
+
+func TestDebtNeverNegative(t *testing.T) {
+	nights := []Night{{TotalSleep: 10 * time.Hour}, {TotalSleep: 7 * time.Hour}}
+	r := AnalyzeNights(nights, 8*time.Hour)
+	if r.Debt[0].Cumulative != 0 || r.TotalDebt != time.Hour {
+		t.Errorf("unexpected debt %+v", r.Debt)
+	}
+}
+
+func TestTargetFromGoals(t *testing.T) {
+	if d := TargetFromGoals(nokiahealth.UserGoals{}); d != DefaultTarget {
+		t.Errorf("expected the default target got %s", d)
+	}
+	sleep := 27000
+	if d := TargetFromGoals(nokiahealth.UserGoals{Sleep: &sleep}); d != 7*time.Hour+30*time.Minute {
+		t.Errorf("expected the user sleep goal got %s", d)
+	}
+}
@@ -0,0 +1,207 @@
+package syncer
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// MeasureGroup is a stored measure group along with the time zone of the
+// user when it was retrieved.
+type MeasureGroup struct {
+	TimeZone string
+	nokiahealth.BodyMeasureGroupResp
+}
+
+// MemoryStorage is a Storage and Reconciler keeping everything in memory. It
+// is safe for concurrent use and mostly useful for tests and short lived
+// processes.
+type MemoryStorage struct {
+	mu          sync.RWMutex
+	checkpoints map[int]map[DataType]time.Time
+	groups      map[int]map[int]MeasureGroup
+	activities  map[int]map[string]nokiahealth.Activity
+	workouts    map[int]map[int]nokiahealth.Workout
+	sleep       map[int]map[int64]nokiahealth.SleepSummary
+}
+
+// NewMemoryStorage returns an empty memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		checkpoints: map[int]map[DataType]time.Time{},
+		groups:      map[int]map[int]MeasureGroup{},
+		activities:  map[int]map[string]nokiahealth.Activity{},
+		workouts:    map[int]map[int]nokiahealth.Workout{},
+		sleep:       map[int]map[int64]nokiahealth.SleepSummary{},
+	}
+}
+
+// Checkpoint implements Storage.
+func (m *MemoryStorage) Checkpoint(ctx context.Context, userID int, t DataType) (time.Time, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.checkpoints[userID][t]
+	return c, ok, nil
+}
+
+// SaveCheckpoint implements Storage.
+func (m *MemoryStorage) SaveCheckpoint(ctx context.Context, userID int, t DataType, lastUpdate time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.checkpoints[userID] == nil {
+		m.checkpoints[userID] = map[DataType]time.Time{}
+	}
+	m.checkpoints[userID][t] = lastUpdate
+	return nil
+}
+
+// UpsertMeasureGroups implements Storage.
+func (m *MemoryStorage) UpsertMeasureGroups(ctx context.Context, userID int, timezone string, groups []nokiahealth.BodyMeasureGroupResp) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.groups[userID] == nil {
+		m.groups[userID] = map[int]MeasureGroup{}
+	}
+	for _, g := range groups {
+		m.groups[userID][g.GrpID] = MeasureGroup{TimeZone: timezone, BodyMeasureGroupResp: g}
+	}
+	return nil
+}
+
+// MeasureGroupsBetween implements Reconciler.
+func (m *MemoryStorage) MeasureGroupsBetween(ctx context.Context, userID int, start time.Time, end time.Time) ([]MeasureGroup, error) {
+	var groups []MeasureGroup
+	for _, g := range m.MeasureGroups(userID) {
+		if g.Date >= start.Unix() && g.Date < end.Unix() {
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
+
+// ApplyMeasureGroupEvents implements Reconciler.
+func (m *MemoryStorage) ApplyMeasureGroupEvents(ctx context.Context, userID int, events []MeasureGroupEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range events {
+		switch e.Kind {
+		case MeasureGroupDeleted:
+			delete(m.groups[userID], e.GrpID)
+		case MeasureGroupUpdated:
+			if e.New == nil {
+				continue
+			}
+			if m.groups[userID] == nil {
+				m.groups[userID] = map[int]MeasureGroup{}
+			}
+			m.groups[userID][e.GrpID] = *e.New
+		}
+	}
+	return nil
+}
+
+// UpsertActivities implements Storage.
+func (m *MemoryStorage) UpsertActivities(ctx context.Context, userID int, activities []nokiahealth.Activity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.activities[userID] == nil {
+		m.activities[userID] = map[string]nokiahealth.Activity{}
+	}
+	for _, a := range activities {
+		m.activities[userID][a.Date] = a
+	}
+	return nil
+}
+
+// UpsertWorkouts implements Storage.
+func (m *MemoryStorage) UpsertWorkouts(ctx context.Context, userID int, workouts []nokiahealth.Workout) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.workouts[userID] == nil {
+		m.workouts[userID] = map[int]nokiahealth.Workout{}
+	}
+	for _, w := range workouts {
+		m.workouts[userID][w.ID] = w
+	}
+	return nil
+}
+
+// UpsertSleepSummaries implements Storage.
+func (m *MemoryStorage) UpsertSleepSummaries(ctx context.Context, userID int, summaries []nokiahealth.SleepSummary) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sleep[userID] == nil {
+		m.sleep[userID] = map[int64]nokiahealth.SleepSummary{}
+	}
+	for _, s := range summaries {
+		m.sleep[userID][s.ID] = s
+	}
+	return nil
+}
+
+// MeasureGroups returns the stored measure groups of the user ordered by date.
+func (m *MemoryStorage) MeasureGroups(userID int) []MeasureGroup {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	groups := make([]MeasureGroup, 0, len(m.groups[userID]))
+	for _, g := range m.groups[userID] {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Date != groups[j].Date {
+			return groups[i].Date < groups[j].Date
+		}
+		return groups[i].GrpID < groups[j].GrpID
+	})
+	return groups
+}
+
+// Activities returns the stored activities of the user ordered by date.
+func (m *MemoryStorage) Activities(userID int) []nokiahealth.Activity {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	activities := make([]nokiahealth.Activity, 0, len(m.activities[userID]))
+	for _, a := range m.activities[userID] {
+		activities = append(activities, a)
+	}
+	sort.Slice(activities, func(i, j int) bool { return activities[i].Date < activities[j].Date })
+	return activities
+}
+
+// Workouts returns the stored workouts of the user ordered by start date.
+func (m *MemoryStorage) Workouts(userID int) []nokiahealth.Workout {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	workouts := make([]nokiahealth.Workout, 0, len(m.workouts[userID]))
+	for _, w := range m.workouts[userID] {
+		workouts = append(workouts, w)
+	}
+	sort.Slice(workouts, func(i, j int) bool {
+		if workouts[i].StartDate != workouts[j].StartDate {
+			return workouts[i].StartDate < workouts[j].StartDate
+		}
+		return workouts[i].ID < workouts[j].ID
+	})
+	return workouts
+}
+
+// SleepSummaries returns the stored sleep summaries of the user ordered by
+// start date.
+func (m *MemoryStorage) SleepSummaries(userID int) []nokiahealth.SleepSummary {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	summaries := make([]nokiahealth.SleepSummary, 0, len(m.sleep[userID]))
+	for _, s := range m.sleep[userID] {
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].StartDate != summaries[j].StartDate {
+			return summaries[i].StartDate < summaries[j].StartDate
+		}
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries
+}
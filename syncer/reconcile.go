@@ -0,0 +1,181 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// EventKind is the kind of change of a stored measure group.
+type EventKind string
+
+// EventKind constants.
+const (
+	MeasureGroupUpdated EventKind = "updated"
+	MeasureGroupDeleted EventKind = "deleted"
+)
+
+// MeasureGroupEvent is a change of a measure group that was already stored.
+// Old is the stored copy and New the one now returned by the API. New is nil
+// when the group was deleted.
+type MeasureGroupEvent struct {
+	Kind   EventKind
+	UserID int
+	GrpID  int
+	Old    MeasureGroup
+	New    *MeasureGroup
+}
+
+// Reconciler is implemented by storages able to apply measure group events.
+// The syncer only detects deleted and updated measure groups when the storage
+// implements it.
+type Reconciler interface {
+	// MeasureGroupsBetween returns the stored measure groups of the user taken
+	// from start until end excluded.
+	MeasureGroupsBetween(ctx context.Context, userID int, start time.Time, end time.Time) ([]MeasureGroup, error)
+	// ApplyMeasureGroupEvents removes the deleted groups and replaces the
+	// updated groups by their new copy.
+	ApplyMeasureGroupEvents(ctx context.Context, userID int, events []MeasureGroupEvent) error
+}
+
+// changed returns true if the group returned by the API differs from the
+// stored copy, either because it was attributed differently or because it was
+// modified since.
+func changed(old MeasureGroup, g nokiahealth.BodyMeasureGroupResp) bool {
+	return old.Attrib != g.Attrib || g.Modified > old.Modified
+}
+
+// updateEvents returns the update events of the groups of a page that were
+// already stored and changed since. Groups not stored yet are not events.
+func updateEvents(ctx context.Context, rec Reconciler, userID int, timezone string, groups []nokiahealth.BodyMeasureGroupResp) ([]MeasureGroupEvent, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	start, end := groups[0].Date, groups[0].Date
+	for _, g := range groups {
+		if g.Date < start {
+			start = g.Date
+		}
+		if g.Date > end {
+			end = g.Date
+		}
+	}
+	stored, err := rec.MeasureGroupsBetween(ctx, userID, time.Unix(start, 0), time.Unix(end+1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored measure groups: %s", err)
+	}
+	byID := make(map[int]MeasureGroup, len(stored))
+	for _, g := range stored {
+		byID[g.GrpID] = g
+	}
+
+	var events []MeasureGroupEvent
+	for _, g := range groups {
+		old, ok := byID[g.GrpID]
+		if !ok || !changed(old, g) {
+			continue
+		}
+		events = append(events, MeasureGroupEvent{
+			Kind:   MeasureGroupUpdated,
+			UserID: userID,
+			GrpID:  g.GrpID,
+			Old:    old,
+			New:    &MeasureGroup{TimeZone: timezone, BodyMeasureGroupResp: g},
+		})
+	}
+	return events, nil
+}
+
+// Reconcile retrieves every measure group of the user taken from start until
+// end excluded and compares them with the stored ones. Stored groups no longer
+// returned by the API are deleted and the ones that changed are updated. The
+// events are applied to the storage and returned. Groups that were not stored
+// yet are upserted without an event.
+//
+// Deleted groups are not returned by the lastupdate parameter of the API so a
+// sync alone never detects them. Reconciling the last days regularly, see
+// Options.ReconcileWindow, keeps the stored copy free of removed weigh-ins.
+func (s *Syncer) Reconcile(ctx context.Context, userID int, api API, start time.Time, end time.Time) ([]MeasureGroupEvent, error) {
+	rec, ok := s.Storage.(Reconciler)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support reconciliation")
+	}
+
+	// Every page is retrieved before anything is changed so a failure, a
+	// missing body or an offset that does not advance never deletes groups
+	// that were simply not retrieved yet.
+	var timezone string
+	fetched := map[int]nokiahealth.BodyMeasureGroupResp{}
+	var fetchedOrder []int
+	err := paginate(&Result{}, func(offset *int) (bool, int, error) {
+		resp, err := api.GetBodyMeasuresCtx(ctx, &nokiahealth.BodyMeasuresQueryParams{UserID: userID, StartDate: &start, EndDate: &end, Offset: offset})
+		if err != nil {
+			return false, 0, err
+		}
+		if resp.Body == nil {
+			return false, 0, errNoBody
+		}
+		timezone = resp.Body.Timezone
+		for _, g := range resp.Body.MeasureGrps {
+			if g.Date < start.Unix() || g.Date >= end.Unix() {
+				continue
+			}
+			if _, ok := fetched[g.GrpID]; !ok {
+				fetchedOrder = append(fetchedOrder, g.GrpID)
+			}
+			fetched[g.GrpID] = g
+		}
+		return resp.Body.More != 0, resp.Body.Offset, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve measure groups: %s", err)
+	}
+
+	stored, err := rec.MeasureGroupsBetween(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored measure groups: %s", err)
+	}
+
+	var events []MeasureGroupEvent
+	isStored := make(map[int]bool, len(stored))
+	for _, old := range stored {
+		isStored[old.GrpID] = true
+		g, ok := fetched[old.GrpID]
+		switch {
+		case !ok:
+			events = append(events, MeasureGroupEvent{Kind: MeasureGroupDeleted, UserID: userID, GrpID: old.GrpID, Old: old})
+		case changed(old, g):
+			tz := timezone
+			if tz == "" {
+				tz = old.TimeZone
+			}
+			events = append(events, MeasureGroupEvent{
+				Kind:   MeasureGroupUpdated,
+				UserID: userID,
+				GrpID:  old.GrpID,
+				Old:    old,
+				New:    &MeasureGroup{TimeZone: tz, BodyMeasureGroupResp: g},
+			})
+		}
+	}
+
+	var missing []nokiahealth.BodyMeasureGroupResp
+	for _, id := range fetchedOrder {
+		if !isStored[id] {
+			missing = append(missing, fetched[id])
+		}
+	}
+	if len(missing) > 0 {
+		if err := s.Storage.UpsertMeasureGroups(ctx, userID, timezone, missing); err != nil {
+			return nil, fmt.Errorf("failed to store measure groups: %s", err)
+		}
+	}
+	if len(events) > 0 {
+		if err := rec.ApplyMeasureGroupEvents(ctx, userID, events); err != nil {
+			return nil, fmt.Errorf("failed to apply measure group events: %s", err)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,202 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+)
+
+// groupAPI serves the measure groups modified since the last update or taken
+// within the date range requested. A non zero pageSize splits them in pages
+// and stuck makes every page after the first repeat the offset requested.
+type groupAPI struct {
+	API
+	groups   []nokiahealth.BodyMeasureGroupResp
+	fail     bool
+	noBody   bool
+	pageSize int
+	stuck    bool
+}
+
+func (f *groupAPI) GetBodyMeasuresCtx(ctx context.Context, p *nokiahealth.BodyMeasuresQueryParams) (nokiahealth.BodyMeasuresResp, error) {
+	if f.fail {
+		return nokiahealth.BodyMeasuresResp{}, errors.New("boom")
+	}
+	if f.noBody {
+		return nokiahealth.BodyMeasuresResp{}, nil
+	}
+	body := &nokiahealth.BodyMeasureRespBody{Timezone: "Europe/Paris"}
+	for _, g := range f.groups {
+		if p.LastUpdate != nil && g.Modified <= p.LastUpdate.Unix() {
+			continue
+		}
+		if p.StartDate != nil && (g.Date < p.StartDate.Unix() || g.Date > p.EndDate.Unix()) {
+			continue
+		}
+		body.MeasureGrps = append(body.MeasureGrps, g)
+	}
+
+	if f.pageSize > 0 {
+		var offset int
+		if p.Offset != nil {
+			offset = *p.Offset
+		}
+		if offset+f.pageSize < len(body.MeasureGrps) {
+			body.More, body.Offset = 1, offset+f.pageSize
+			if f.stuck && p.Offset != nil {
+				body.Offset = offset
+			}
+			body.MeasureGrps = body.MeasureGrps[offset : offset+f.pageSize]
+		} else if offset < len(body.MeasureGrps) {
+			body.MeasureGrps = body.MeasureGrps[offset:]
+		} else {
+			body.MeasureGrps = nil
+		}
+	}
+	return nokiahealth.BodyMeasuresResp{Body: body}, nil
+}
+
+func grpIDs(groups []MeasureGroup) []int {
+	var ids []int
+	for _, g := range groups {
+		ids = append(ids, g.GrpID)
+	}
+	return ids
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryStorage()
+	now := time.Unix(400, 0)
+	s := New(storage, Options{
+		Types:           []DataType{BodyMeasures},
+		Now:             func() time.Time { return now },
+		ReconcileWindow: 1000 * time.Second,
+	})
+	api := &groupAPI{groups: []nokiahealth.BodyMeasureGroupResp{
+		{GrpID: 1, Date: 100, Modified: 100},
+		{GrpID: 2, Date: 200, Modified: 200},
+		{GrpID: 3, Date: 300, Modified: 300},
+	}}
+
+	results, err := s.Sync(ctx, 42, api)
+	if err != nil {
+		t.Fatalf("failed to sync: %s", err)
+	}
+	if len(results[0].Events) != 0 || len(storage.MeasureGroups(42)) != 3 {
+		t.Fatalf("expected the groups to be stored without events got %+v", results[0])
+	}
+
+	// Group 1 is attributed to someone else, 2 is deleted and 4 is new.
+	api.groups = []nokiahealth.BodyMeasureGroupResp{
+		{GrpID: 1, Date: 100, Modified: 500, Attrib: attrib.DeviceEntryForUserAmbiguous},
+		{GrpID: 3, Date: 300, Modified: 300},
+		{GrpID: 4, Date: 350, Modified: 500},
+	}
+	now = time.Unix(1000, 0)
+	results, err = s.Sync(ctx, 42, api)
+	if err != nil {
+		t.Fatalf("failed to sync: %s", err)
+	}
+	events := results[0].Events
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events got %+v", events)
+	}
+	if e := events[0]; e.Kind != MeasureGroupUpdated || e.GrpID != 1 || e.UserID != 42 || e.Old.Attrib != attrib.DeviceEntryForUser ||
+		e.New == nil || e.New.Attrib != attrib.DeviceEntryForUserAmbiguous || e.New.TimeZone != "Europe/Paris" {
+		t.Errorf("unexpected update event %+v", e)
+	}
+	if e := events[1]; e.Kind != MeasureGroupDeleted || e.GrpID != 2 || e.Old.GrpID != 2 || e.New != nil {
+		t.Errorf("unexpected delete event %+v", e)
+	}
+
+	groups := storage.MeasureGroups(42)
+	if ids := grpIDs(groups); len(ids) != 3 || ids[0] != 1 || ids[1] != 3 || ids[2] != 4 {
+		t.Fatalf("unexpected stored groups %v", ids)
+	}
+	if groups[0].Attrib != attrib.DeviceEntryForUserAmbiguous {
+		t.Errorf("expected the update to be applied got %+v", groups[0])
+	}
+
+	// Reconciling again finds nothing new.
+	events, err = s.Reconcile(ctx, 42, api, time.Unix(0, 0), now)
+	if err != nil || len(events) != 0 {
+		t.Errorf("expected no events got %+v %s", events, err)
+	}
+}
+
+func TestReconcileOnlyWithinRange(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryStorage()
+	storage.UpsertMeasureGroups(ctx, 42, "UTC", []nokiahealth.BodyMeasureGroupResp{{GrpID: 1, Date: 100}, {GrpID: 2, Date: 200}})
+	s := New(storage, Options{})
+
+	// Groups outside of the range are never deleted.
+	events, err := s.Reconcile(ctx, 42, &groupAPI{}, time.Unix(150, 0), time.Unix(300, 0))
+	if err != nil || len(events) != 1 || events[0].GrpID != 2 {
+		t.Fatalf("expected only group 2 to be deleted got %+v %s", events, err)
+	}
+	if ids := grpIDs(storage.MeasureGroups(42)); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("unexpected stored groups %v", ids)
+	}
+
+	// Nothing is deleted when the groups can not be retrieved.
+	if _, err := s.Reconcile(ctx, 42, &groupAPI{fail: true}, time.Unix(0, 0), time.Unix(300, 0)); err == nil {
+		t.Errorf("expected the error of the API")
+	}
+	if len(storage.MeasureGroups(42)) != 1 {
+		t.Errorf("expected the stored groups to be kept")
+	}
+}
+
+func TestReconcileIncompletePages(t *testing.T) {
+	ctx := context.Background()
+	groups := []nokiahealth.BodyMeasureGroupResp{{GrpID: 1, Date: 100}, {GrpID: 2, Date: 200}, {GrpID: 3, Date: 300}}
+	storage := NewMemoryStorage()
+	storage.UpsertMeasureGroups(ctx, 42, "UTC", groups)
+	s := New(storage, Options{})
+	start, end := time.Unix(0, 0), time.Unix(1000, 0)
+
+	// The pages are followed until the last one.
+	events, err := s.Reconcile(ctx, 42, &groupAPI{groups: groups, pageSize: 1}, start, end)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("expected no events got %+v %s", events, err)
+	}
+
+	// Page 2 repeats its offset so page 3 is never retrieved.
+	events, err = s.Reconcile(ctx, 42, &groupAPI{groups: groups, pageSize: 1, stuck: true}, start, end)
+	if err == nil || len(events) != 0 {
+		t.Errorf("expected an error and no events got %+v %v", events, err)
+	}
+	if ids := grpIDs(storage.MeasureGroups(42)); len(ids) != 3 {
+		t.Errorf("expected nothing to be deleted got %v", ids)
+	}
+
+	events, err = s.Reconcile(ctx, 42, &groupAPI{groups: groups, noBody: true}, start, end)
+	if err == nil || len(events) != 0 {
+		t.Errorf("expected an error and no events got %+v %v", events, err)
+	}
+	if ids := grpIDs(storage.MeasureGroups(42)); len(ids) != 3 {
+		t.Errorf("expected nothing to be deleted got %v", ids)
+	}
+}
+
+// plainStorage hides the reconciler of the memory storage.
+type plainStorage struct {
+	Storage
+}
+
+func TestReconcileUnsupported(t *testing.T) {
+	s := New(plainStorage{NewMemoryStorage()}, Options{ReconcileWindow: time.Hour})
+	if _, err := s.Reconcile(context.Background(), 42, &groupAPI{}, time.Unix(0, 0), time.Unix(300, 0)); err == nil {
+		t.Errorf("expected an error for a storage without reconciler")
+	}
+	// Syncing still works without reconciling.
+	if _, err := s.SyncType(context.Background(), 42, &groupAPI{}, BodyMeasures); err != nil {
+		t.Errorf("failed to sync: %s", err)
+	}
+}
@@ -0,0 +1,9 @@
+package sqlitestore
+
+import (
+	// The pure Go SQLite driver registers itself as DriverName.
+	_ "modernc.org/sqlite"
+)
+
+// DriverName is the database/sql driver used by Open.
+const DriverName = "sqlite"
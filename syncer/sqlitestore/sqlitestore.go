@@ -0,0 +1,497 @@
+// Package sqlitestore is a syncer.Storage backed by SQLite using a pure Go
+// driver so no cgo is needed.
+//
+// Records are keyed by user and the IDs of the API so syncing the same data
+// again replaces the stored copy. The complete records are stored as JSON
+// next to the columns used to query them so nothing returned by the API is
+// lost.
+//
+// The modernc.org/sqlite driver is not vendored so modules importing this
+// package must require it with go get modernc.org/sqlite. A store can also be
+// created with New from a database opened with another SQLite driver.
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+	"github.com/jrmycanady/nokiahealth/enum/category"
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+	"github.com/jrmycanady/nokiahealth/syncer"
+)
+
+// schema creates the tables if they do not exist yet.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS checkpoints (
+		user_id     INTEGER NOT NULL,
+		data_type   TEXT    NOT NULL,
+		last_update INTEGER NOT NULL,
+		PRIMARY KEY (user_id, data_type)
+	)`,
+	`CREATE TABLE IF NOT EXISTS measure_groups (
+		user_id  INTEGER NOT NULL,
+		grp_id   INTEGER NOT NULL,
+		date     INTEGER NOT NULL,
+		created  INTEGER NOT NULL,
+		modified INTEGER NOT NULL,
+		attrib   INTEGER NOT NULL,
+		category INTEGER NOT NULL,
+		timezone TEXT    NOT NULL,
+		PRIMARY KEY (user_id, grp_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS measure_groups_date ON measure_groups (user_id, date)`,
+	`CREATE TABLE IF NOT EXISTS measures (
+		user_id  INTEGER NOT NULL,
+		grp_id   INTEGER NOT NULL,
+		type     INTEGER NOT NULL,
+		position INTEGER NOT NULL,
+		value    INTEGER NOT NULL,
+		unit     INTEGER NOT NULL,
+		PRIMARY KEY (user_id, grp_id, type, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS activities (
+		user_id  INTEGER NOT NULL,
+		date     TEXT    NOT NULL,
+		timezone TEXT    NOT NULL,
+		steps    REAL    NOT NULL,
+		calories REAL    NOT NULL,
+		data     TEXT    NOT NULL,
+		PRIMARY KEY (user_id, date)
+	)`,
+	`CREATE TABLE IF NOT EXISTS workouts (
+		user_id    INTEGER NOT NULL,
+		id         INTEGER NOT NULL,
+		category   INTEGER,
+		start_date INTEGER NOT NULL,
+		end_date   INTEGER NOT NULL,
+		timezone   TEXT    NOT NULL,
+		data       TEXT    NOT NULL,
+		PRIMARY KEY (user_id, id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS workouts_start_date ON workouts (user_id, start_date)`,
+	`CREATE TABLE IF NOT EXISTS sleep_summaries (
+		user_id    INTEGER NOT NULL,
+		id         INTEGER NOT NULL,
+		start_date INTEGER NOT NULL,
+		end_date   INTEGER NOT NULL,
+		timezone   TEXT    NOT NULL,
+		data       TEXT    NOT NULL,
+		PRIMARY KEY (user_id, id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS sleep_summaries_start_date ON sleep_summaries (user_id, start_date)`,
+	`CREATE TABLE IF NOT EXISTS sleep_states (
+		user_id    INTEGER NOT NULL,
+		start_date INTEGER NOT NULL,
+		end_date   INTEGER NOT NULL,
+		state      INTEGER NOT NULL,
+		data       TEXT    NOT NULL,
+		PRIMARY KEY (user_id, start_date, end_date)
+	)`,
+	`CREATE TABLE IF NOT EXISTS intraday (
+		user_id   INTEGER NOT NULL,
+		timestamp INTEGER NOT NULL,
+		timezone  TEXT    NOT NULL,
+		data      TEXT    NOT NULL,
+		PRIMARY KEY (user_id, timestamp)
+	)`,
+}
+
+// Store is a syncer.Storage and syncer.Reconciler backed by SQLite. It also
+// stores the sleep measures and intraday activity which are not synced
+// incrementally.
+type Store struct {
+	DB *sql.DB
+}
+
+// Open opens or creates the SQLite database at the path provided and creates
+// the schema if needed.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open(DriverName, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %s", err)
+	}
+	// SQLite only supports a single writer.
+	db.SetMaxOpenConns(1)
+
+	s, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New returns a store using the SQLite database provided and creates the
+// schema if needed.
+func New(db *sql.DB) (*Store, error) {
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create schema: %s", err)
+		}
+	}
+	return &Store{DB: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.DB.Close()
+}
+
+// tx runs fn in a transaction committing it if fn succeeds.
+func (s *Store) tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Checkpoint implements syncer.Storage.
+func (s *Store) Checkpoint(ctx context.Context, userID int, t syncer.DataType) (time.Time, bool, error) {
+	var lastUpdate int64
+	err := s.DB.QueryRowContext(ctx, `SELECT last_update FROM checkpoints WHERE user_id = ? AND data_type = ?`, userID, string(t)).Scan(&lastUpdate)
+	if err == sql.ErrNoRows {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.Unix(lastUpdate, 0), true, nil
+}
+
+// SaveCheckpoint implements syncer.Storage.
+func (s *Store) SaveCheckpoint(ctx context.Context, userID int, t syncer.DataType, lastUpdate time.Time) error {
+	_, err := s.DB.ExecContext(ctx, `INSERT OR REPLACE INTO checkpoints (user_id, data_type, last_update) VALUES (?, ?, ?)`, userID, string(t), lastUpdate.Unix())
+	return err
+}
+
+// UpsertMeasureGroups implements syncer.Storage. The measures of a group are
+// replaced by the ones provided.
+func (s *Store) UpsertMeasureGroups(ctx context.Context, userID int, timezone string, groups []nokiahealth.BodyMeasureGroupResp) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, g := range groups {
+			if err := upsertMeasureGroup(ctx, tx, userID, timezone, g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// upsertMeasureGroup replaces the measure group and its measures.
+func upsertMeasureGroup(ctx context.Context, tx *sql.Tx, userID int, timezone string, g nokiahealth.BodyMeasureGroupResp) error {
+	if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO measure_groups (user_id, grp_id, date, created, modified, attrib, category, timezone) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		userID, g.GrpID, g.Date, g.Created, g.Modified, int(g.Attrib), int(g.Category), timezone); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM measures WHERE user_id = ? AND grp_id = ?`, userID, g.GrpID); err != nil {
+		return err
+	}
+	for _, m := range g.Measures {
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO measures (user_id, grp_id, type, position, value, unit) VALUES (?, ?, ?, ?, ?, ?)`,
+			userID, g.GrpID, int(m.Type), m.Position, m.Value, m.Unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyMeasureGroupEvents implements syncer.Reconciler.
+func (s *Store) ApplyMeasureGroupEvents(ctx context.Context, userID int, events []syncer.MeasureGroupEvent) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, e := range events {
+			switch e.Kind {
+			case syncer.MeasureGroupDeleted:
+				if _, err := tx.ExecContext(ctx, `DELETE FROM measures WHERE user_id = ? AND grp_id = ?`, userID, e.GrpID); err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, `DELETE FROM measure_groups WHERE user_id = ? AND grp_id = ?`, userID, e.GrpID); err != nil {
+					return err
+				}
+			case syncer.MeasureGroupUpdated:
+				if e.New == nil {
+					continue
+				}
+				if err := upsertMeasureGroup(ctx, tx, userID, e.New.TimeZone, e.New.BodyMeasureGroupResp); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertActivities implements syncer.Storage.
+func (s *Store) UpsertActivities(ctx context.Context, userID int, activities []nokiahealth.Activity) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, a := range activities {
+			data, err := json.Marshal(a)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO activities (user_id, date, timezone, steps, calories, data) VALUES (?, ?, ?, ?, ?, ?)`,
+				userID, a.Date, a.TimeZone, a.Steps, a.Calories, string(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertWorkouts implements syncer.Storage.
+func (s *Store) UpsertWorkouts(ctx context.Context, userID int, workouts []nokiahealth.Workout) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, w := range workouts {
+			data, err := json.Marshal(w)
+			if err != nil {
+				return err
+			}
+			var workoutType *int
+			if w.Category != nil {
+				c := int(*w.Category)
+				workoutType = &c
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO workouts (user_id, id, category, start_date, end_date, timezone, data) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				userID, w.ID, workoutType, w.StartDate, w.EndDate, w.TimeZone, string(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertSleepSummaries implements syncer.Storage.
+func (s *Store) UpsertSleepSummaries(ctx context.Context, userID int, summaries []nokiahealth.SleepSummary) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, ss := range summaries {
+			data, err := json.Marshal(ss)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO sleep_summaries (user_id, id, start_date, end_date, timezone, data) VALUES (?, ?, ?, ?, ?, ?)`,
+				userID, ss.ID, ss.StartDate, ss.EndDate, ss.TimeZone, string(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertSleepMeasures stores the sleep states of the measures identified by
+// their start and end dates.
+func (s *Store) UpsertSleepMeasures(ctx context.Context, userID int, measures []nokiahealth.SleepMeasure) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, m := range measures {
+			data, err := json.Marshal(m)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO sleep_states (user_id, start_date, end_date, state, data) VALUES (?, ?, ?, ?, ?)`,
+				userID, m.StartDate, m.EndDate, int(m.State), string(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertIntradayActivity stores the intraday activity of the response
+// identified by its timestamp. The API does not return the time zone of the
+// samples so the IANA name of the one they were recorded in must be provided
+// to restore their dates.
+func (s *Store) UpsertIntradayActivity(ctx context.Context, userID int, timezone string, resp nokiahealth.IntradayActivityResp) error {
+	return s.tx(ctx, func(tx *sql.Tx) error {
+		for _, sample := range resp.Samples() {
+			data, err := json.Marshal(sample.IntraDayActivity)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO intraday (user_id, timestamp, timezone, data) VALUES (?, ?, ?, ?)`,
+				userID, sample.Date.Unix(), timezone, string(data)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MeasureGroups returns the measure groups of the user taken from start until
+// end excluded, ordered by date.
+func (s *Store) MeasureGroups(ctx context.Context, userID int, start time.Time, end time.Time) ([]syncer.MeasureGroup, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT grp_id, date, created, modified, attrib, category, timezone FROM measure_groups
+		WHERE user_id = ? AND date >= ? AND date < ? ORDER BY date, grp_id`, userID, start.Unix(), end.Unix())
+	if err != nil {
+		return nil, err
+	}
+	var groups []syncer.MeasureGroup
+	index := map[int]int{}
+	for rows.Next() {
+		var g syncer.MeasureGroup
+		var a, c int
+		if err := rows.Scan(&g.GrpID, &g.Date, &g.Created, &g.Modified, &a, &c, &g.TimeZone); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		g.Attrib = attrib.Attrib(a)
+		g.Category = category.Category(c)
+		index[g.GrpID] = len(groups)
+		groups = append(groups, g)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = s.DB.QueryContext(ctx, `SELECT m.grp_id, m.type, m.position, m.value, m.unit FROM measures m
+		JOIN measure_groups g ON g.user_id = m.user_id AND g.grp_id = m.grp_id
+		WHERE m.user_id = ? AND g.date >= ? AND g.date < ? ORDER BY m.grp_id, m.type, m.position`, userID, start.Unix(), end.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var grpID, typ int
+		var m nokiahealth.BodyMeasuresMeasure
+		if err := rows.Scan(&grpID, &typ, &m.Position, &m.Value, &m.Unit); err != nil {
+			return nil, err
+		}
+		m.Type = meastype.MeasType(typ)
+		if i, ok := index[grpID]; ok {
+			groups[i].Measures = append(groups[i].Measures, m)
+		}
+	}
+	return groups, rows.Err()
+}
+
+// MeasureGroupsBetween implements syncer.Reconciler. It is the same as
+// MeasureGroups.
+func (s *Store) MeasureGroupsBetween(ctx context.Context, userID int, start time.Time, end time.Time) ([]syncer.MeasureGroup, error) {
+	return s.MeasureGroups(ctx, userID, start, end)
+}
+
+// Activities returns the activities of the user whose date is from the date
+// of start until the date of end excluded, ordered by date.
+func (s *Store) Activities(ctx context.Context, userID int, start time.Time, end time.Time) ([]nokiahealth.Activity, error) {
+	var activities []nokiahealth.Activity
+	err := s.query(ctx, func(data []byte) error {
+		var a nokiahealth.Activity
+		if err := json.Unmarshal(data, &a); err != nil {
+			return err
+		}
+		activities = append(activities, a)
+		return nil
+	}, `SELECT data FROM activities WHERE user_id = ? AND date >= ? AND date < ? ORDER BY date`,
+		userID, nokiahealth.CivilDate(start), nokiahealth.CivilDate(end))
+	return activities, err
+}
+
+// Workouts returns the workouts of the user started from start until end
+// excluded, ordered by start date.
+func (s *Store) Workouts(ctx context.Context, userID int, start time.Time, end time.Time) ([]nokiahealth.Workout, error) {
+	var workouts []nokiahealth.Workout
+	err := s.query(ctx, func(data []byte) error {
+		var w nokiahealth.Workout
+		if err := json.Unmarshal(data, &w); err != nil {
+			return err
+		}
+		workouts = append(workouts, w)
+		return nil
+	}, `SELECT data FROM workouts WHERE user_id = ? AND start_date >= ? AND start_date < ? ORDER BY start_date, id`,
+		userID, start.Unix(), end.Unix())
+	return workouts, err
+}
+
+// SleepSummaries returns the sleep summaries of the user started from start
+// until end excluded, ordered by start date.
+func (s *Store) SleepSummaries(ctx context.Context, userID int, start time.Time, end time.Time) ([]nokiahealth.SleepSummary, error) {
+	var summaries []nokiahealth.SleepSummary
+	err := s.query(ctx, func(data []byte) error {
+		var ss nokiahealth.SleepSummary
+		if err := json.Unmarshal(data, &ss); err != nil {
+			return err
+		}
+		summaries = append(summaries, ss)
+		return nil
+	}, `SELECT data FROM sleep_summaries WHERE user_id = ? AND start_date >= ? AND start_date < ? ORDER BY start_date, id`,
+		userID, start.Unix(), end.Unix())
+	return summaries, err
+}
+
+// SleepMeasures returns the sleep measures of the user started from start
+// until end excluded, ordered by start date.
+func (s *Store) SleepMeasures(ctx context.Context, userID int, start time.Time, end time.Time) ([]nokiahealth.SleepMeasure, error) {
+	var measures []nokiahealth.SleepMeasure
+	err := s.query(ctx, func(data []byte) error {
+		var m nokiahealth.SleepMeasure
+		if err := json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+		measures = append(measures, m)
+		return nil
+	}, `SELECT data FROM sleep_states WHERE user_id = ? AND start_date >= ? AND start_date < ? ORDER BY start_date, end_date`,
+		userID, start.Unix(), end.Unix())
+	return measures, err
+}
+
+// IntradaySamples returns the intraday activity of the user from start until
+// end excluded, ordered by date. The dates are in the time zone the samples
+// were stored with.
+func (s *Store) IntradaySamples(ctx context.Context, userID int, start time.Time, end time.Time) ([]nokiahealth.IntradaySample, error) {
+	rows, err := s.DB.QueryContext(ctx, `SELECT timestamp, timezone, data FROM intraday WHERE user_id = ? AND timestamp >= ? AND timestamp < ? ORDER BY timestamp`,
+		userID, start.Unix(), end.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []nokiahealth.IntradaySample
+	for rows.Next() {
+		var ts int64
+		var tz string
+		var data []byte
+		if err := rows.Scan(&ts, &tz, &data); err != nil {
+			return nil, err
+		}
+		sample := nokiahealth.IntradaySample{Date: nokiahealth.ParseUnix(ts, tz)}
+		if err := json.Unmarshal(data, &sample.IntraDayActivity); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+// query runs a query selecting a single data column and calls fn for every row.
+func (s *Store) query(ctx context.Context, fn func(data []byte) error, query string, args ...interface{}) error {
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Ensure the store implements the storage and reconciler of the syncer.
+var (
+	_ syncer.Storage    = (*Store)(nil)
+	_ syncer.Reconciler = (*Store)(nil)
+)
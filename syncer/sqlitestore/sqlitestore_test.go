@@ -0,0 +1,220 @@
+package sqlitestore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+	"github.com/jrmycanady/nokiahealth/enum/sleepstate"
+	"github.com/jrmycanady/nokiahealth/enum/workouttype"
+	"github.com/jrmycanady/nokiahealth/syncer"
+)
+
+func openStore(t *testing.T) *Store {
+	s, err := Open(filepath.Join(t.TempDir(), "health.db"))
+	if err != nil {
+		t.Fatalf("failed to open store: %s", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+
+	if _, ok, err := s.Checkpoint(ctx, 1, syncer.Workouts); err != nil || ok {
+		t.Fatalf("expected no checkpoint got %v %s", ok, err)
+	}
+	for _, ts := range []int64{100, 200} {
+		if err := s.SaveCheckpoint(ctx, 1, syncer.Workouts, time.Unix(ts, 0)); err != nil {
+			t.Fatalf("failed to save checkpoint: %s", err)
+		}
+	}
+	c, ok, err := s.Checkpoint(ctx, 1, syncer.Workouts)
+	if err != nil || !ok || c.Unix() != 200 {
+		t.Errorf("expected checkpoint 200 got %v %v %s", c, ok, err)
+	}
+	if _, ok, _ := s.Checkpoint(ctx, 2, syncer.Workouts); ok {
+		t.Errorf("expected checkpoints to be per user")
+	}
+}
+
+func TestMeasureGroups(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+
+	groups := []nokiahealth.BodyMeasureGroupResp{
+		{GrpID: 1, Date: 100, Attrib: attrib.MeasureUserConfirmed, Measures: []nokiahealth.BodyMeasuresMeasure{
+			{Value: 7000, Unit: -2, Type: meastype.Weight},
+			{Value: 20, Unit: 0, Type: meastype.FatMassWeightKg, Position: 1},
+			{Value: 21, Unit: 0, Type: meastype.FatMassWeightKg, Position: 2},
+		}},
+		{GrpID: 2, Date: 200, Measures: []nokiahealth.BodyMeasuresMeasure{{Value: 7100, Unit: -2, Type: meastype.Weight}}},
+	}
+	if err := s.UpsertMeasureGroups(ctx, 1, "Europe/Paris", groups); err != nil {
+		t.Fatalf("failed to upsert: %s", err)
+	}
+	// Upserting again replaces the group and its measures.
+	groups[0].Measures = groups[0].Measures[:1]
+	if err := s.UpsertMeasureGroups(ctx, 1, "Europe/Paris", groups[:1]); err != nil {
+		t.Fatalf("failed to upsert: %s", err)
+	}
+
+	got, err := s.MeasureGroups(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil {
+		t.Fatalf("failed to query: %s", err)
+	}
+	if len(got) != 2 || got[0].GrpID != 1 || got[1].GrpID != 2 {
+		t.Fatalf("unexpected groups %+v", got)
+	}
+	if got[0].TimeZone != "Europe/Paris" || got[0].Attrib != attrib.MeasureUserConfirmed {
+		t.Errorf("unexpected group %+v", got[0])
+	}
+	if len(got[0].Measures) != 1 || got[0].Measures[0] != groups[0].Measures[0] {
+		t.Errorf("expected the measures to be replaced got %+v", got[0].Measures)
+	}
+
+	got, err = s.MeasureGroups(ctx, 1, time.Unix(100, 0), time.Unix(200, 0))
+	if err != nil || len(got) != 1 || got[0].GrpID != 1 {
+		t.Errorf("expected the end to be excluded got %+v %s", got, err)
+	}
+}
+
+func TestApplyMeasureGroupEvents(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+
+	weight := []nokiahealth.BodyMeasuresMeasure{{Value: 7000, Unit: -2, Type: meastype.Weight}}
+	if err := s.UpsertMeasureGroups(ctx, 1, "UTC", []nokiahealth.BodyMeasureGroupResp{
+		{GrpID: 1, Date: 100, Modified: 100, Measures: weight},
+		{GrpID: 2, Date: 200, Modified: 200, Measures: weight},
+	}); err != nil {
+		t.Fatalf("failed to upsert: %s", err)
+	}
+	stored, err := s.MeasureGroupsBetween(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(stored) != 2 || stored[1].Modified != 200 {
+		t.Fatalf("unexpected groups %+v %s", stored, err)
+	}
+
+	updated := stored[0]
+	updated.Attrib = attrib.DeviceEntryForUserAmbiguous
+	updated.Modified = 500
+	updated.TimeZone = "Europe/Paris"
+	if err := s.ApplyMeasureGroupEvents(ctx, 1, []syncer.MeasureGroupEvent{
+		{Kind: syncer.MeasureGroupUpdated, UserID: 1, GrpID: 1, Old: stored[0], New: &updated},
+		{Kind: syncer.MeasureGroupDeleted, UserID: 1, GrpID: 2, Old: stored[1]},
+	}); err != nil {
+		t.Fatalf("failed to apply events: %s", err)
+	}
+
+	got, err := s.MeasureGroups(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(got) != 1 {
+		t.Fatalf("expected the deleted group to be removed got %+v %s", got, err)
+	}
+	if g := got[0]; g.GrpID != 1 || g.Attrib != attrib.DeviceEntryForUserAmbiguous || g.Modified != 500 || g.TimeZone != "Europe/Paris" || len(g.Measures) != 1 {
+		t.Errorf("expected the updated group got %+v", g)
+	}
+	var count int
+	if err := s.DB.QueryRow(`SELECT COUNT(*) FROM measures WHERE user_id = 1 AND grp_id = 2`).Scan(&count); err != nil || count != 0 {
+		t.Errorf("expected the measures of the deleted group to be removed got %d %s", count, err)
+	}
+}
+
+func TestRecords(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+
+	walk := workouttype.Walk
+	if err := s.UpsertActivities(ctx, 1, []nokiahealth.Activity{
+		{Date: "2018-07-01", TimeZone: "Europe/Paris", Steps: 10},
+		{Date: "2018-07-02", TimeZone: "Europe/Paris", Steps: 20},
+	}); err != nil {
+		t.Fatalf("failed to upsert activities: %s", err)
+	}
+	if err := s.UpsertActivities(ctx, 1, []nokiahealth.Activity{{Date: "2018-07-01", TimeZone: "Europe/Paris", Steps: 15}}); err != nil {
+		t.Fatalf("failed to upsert activities: %s", err)
+	}
+	if err := s.UpsertWorkouts(ctx, 1, []nokiahealth.Workout{
+		{ID: 1, Category: &walk, StartDate: 100, EndDate: 150},
+		{ID: 2, StartDate: 300, EndDate: 350},
+	}); err != nil {
+		t.Fatalf("failed to upsert workouts: %s", err)
+	}
+	if err := s.UpsertSleepSummaries(ctx, 1, []nokiahealth.SleepSummary{{ID: 9, StartDate: 100, EndDate: 200}}); err != nil {
+		t.Fatalf("failed to upsert sleep summaries: %s", err)
+	}
+	if err := s.UpsertSleepMeasures(ctx, 1, []nokiahealth.SleepMeasure{
+		{StartDate: 100, EndDate: 160, State: sleepstate.DeepSleep, HR: map[int64]float64{100: 50}},
+	}); err != nil {
+		t.Fatalf("failed to upsert sleep measures: %s", err)
+	}
+	steps := 12
+	if err := s.UpsertIntradayActivity(ctx, 1, "Asia/Tokyo", nokiahealth.IntradayActivityResp{Body: &nokiahealth.IntradayActivityRespBody{
+		Series: map[int64]nokiahealth.IntraDayActivity{120: {Steps: &steps}, 60: {}},
+	}}); err != nil {
+		t.Fatalf("failed to upsert intraday activity: %s", err)
+	}
+
+	paris, _ := nokiahealth.LoadLocation("Europe/Paris")
+	activities, err := s.Activities(ctx, 1, time.Date(2018, 7, 1, 0, 0, 0, 0, paris), time.Date(2018, 7, 2, 0, 0, 0, 0, paris))
+	if err != nil || len(activities) != 1 || activities[0].Steps != 15 {
+		t.Errorf("unexpected activities %+v %s", activities, err)
+	}
+
+	workouts, err := s.Workouts(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(workouts) != 1 || workouts[0].ID != 1 || workouts[0].Category == nil || *workouts[0].Category != walk {
+		t.Errorf("unexpected workouts %+v %s", workouts, err)
+	}
+
+	summaries, err := s.SleepSummaries(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(summaries) != 1 || summaries[0].ID != 9 {
+		t.Errorf("unexpected sleep summaries %+v %s", summaries, err)
+	}
+
+	measures, err := s.SleepMeasures(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(measures) != 1 || measures[0].State != sleepstate.DeepSleep || measures[0].HR[100] != 50 {
+		t.Errorf("unexpected sleep measures %+v %s", measures, err)
+	}
+
+	samples, err := s.IntradaySamples(ctx, 1, time.Unix(0, 0), time.Unix(300, 0))
+	if err != nil || len(samples) != 2 || samples[0].Date.Unix() != 60 || samples[1].Steps == nil || *samples[1].Steps != 12 {
+		t.Errorf("unexpected intraday samples %+v %s", samples, err)
+	} else if samples[0].Date.Location().String() != "Asia/Tokyo" {
+		t.Errorf("expected the samples to be restored in their time zone got %s", samples[0].Date.Location())
+	}
+
+	if workouts, _ := s.Workouts(ctx, 2, time.Unix(0, 0), time.Unix(1000, 0)); len(workouts) != 0 {
+		t.Errorf("expected records to be per user got %+v", workouts)
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	s := openStore(t)
+
+	// The store is usable as the storage of a syncer.
+	sy := syncer.New(s, syncer.Options{Types: []syncer.DataType{syncer.Workouts}})
+	if _, err := sy.Sync(ctx, 1, api{}); err != nil {
+		t.Fatalf("failed to sync: %s", err)
+	}
+	if _, ok, _ := s.Checkpoint(ctx, 1, syncer.Workouts); !ok {
+		t.Errorf("expected a checkpoint to be saved")
+	}
+	if workouts, _ := s.Workouts(ctx, 1, time.Unix(0, 0), time.Unix(1000, 0)); len(workouts) != 1 {
+		t.Errorf("expected the workout to be stored got %+v", workouts)
+	}
+}
+
+// api serves a single workout.
+type api struct {
+	syncer.API
+}
+
+func (api) GetWorkoutsCtx(ctx context.Context, p *nokiahealth.WorkoutsQueryParam) (nokiahealth.WorkoutResponse, error) {
+	return nokiahealth.WorkoutResponse{Body: &nokiahealth.WorkoutRespBody{Series: []nokiahealth.Workout{{ID: 3, StartDate: 500}}}}, nil
+}
@@ -0,0 +1,326 @@
+// Package syncer keeps a local copy of the data of Nokia Health users up to
+// date. For every user and data type a checkpoint records when the data was
+// last synced so only the changes since then are requested with the
+// lastupdate parameter of the API. Every page of changes is handed to a
+// Storage which upserts it.
+//
+// Storages implementing Reconciler are also told about measure groups that
+// were deleted or attributed differently since they were stored, see
+// Syncer.Reconcile.
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// DataType identifies a kind of data that is synced.
+type DataType string
+
+// DataType constants.
+const (
+	BodyMeasures   DataType = "body_measures"
+	Activities     DataType = "activities"
+	Workouts       DataType = "workouts"
+	SleepSummaries DataType = "sleep_summaries"
+)
+
+// DataTypes lists every data type that can be synced.
+var DataTypes = []DataType{BodyMeasures, Activities, Workouts, SleepSummaries}
+
+// API is the part of the client used to sync. It is implemented by
+// *nokiahealth.User.
+type API interface {
+	GetBodyMeasuresCtx(ctx context.Context, params *nokiahealth.BodyMeasuresQueryParams) (nokiahealth.BodyMeasuresResp, error)
+	GetActivityMeasuresCtx(ctx context.Context, params *nokiahealth.ActivityMeasuresQueryParam) (nokiahealth.ActivitiesMeasuresResp, error)
+	GetWorkoutsCtx(ctx context.Context, params *nokiahealth.WorkoutsQueryParam) (nokiahealth.WorkoutResponse, error)
+	GetSleepSummaryCtx(ctx context.Context, params *nokiahealth.SleepSummaryQueryParam) (nokiahealth.SleepSummaryResp, error)
+}
+
+// Storage persists the synced data and checkpoints. Upserts must be
+// idempotent as a page may be stored again if a sync is interrupted before
+// its checkpoint is saved.
+type Storage interface {
+	// Checkpoint returns the last update of the data type for the user. The
+	// second return value is false if the data type was never synced.
+	Checkpoint(ctx context.Context, userID int, t DataType) (time.Time, bool, error)
+	SaveCheckpoint(ctx context.Context, userID int, t DataType, lastUpdate time.Time) error
+
+	// UpsertMeasureGroups stores the measure groups identified by GrpID.
+	// The time zone is the one of the user when the groups were retrieved.
+	UpsertMeasureGroups(ctx context.Context, userID int, timezone string, groups []nokiahealth.BodyMeasureGroupResp) error
+	// UpsertActivities stores the activities identified by their date.
+	UpsertActivities(ctx context.Context, userID int, activities []nokiahealth.Activity) error
+	// UpsertWorkouts stores the workouts identified by ID.
+	UpsertWorkouts(ctx context.Context, userID int, workouts []nokiahealth.Workout) error
+	// UpsertSleepSummaries stores the sleep summaries identified by ID.
+	UpsertSleepSummaries(ctx context.Context, userID int, summaries []nokiahealth.SleepSummary) error
+}
+
+// Options configures a Syncer. The zero value uses the defaults.
+type Options struct {
+	// Types are the data types synced. Defaults to DataTypes.
+	Types []DataType
+	// Since is the last update used the first time a data type is synced
+	// for a user. Defaults to the unix epoch so all the data is retrieved.
+	Since time.Time
+	// Now returns the current time. It is used as the checkpoint when the
+	// API does not return its own update time. Defaults to time.Now.
+	Now func() time.Time
+	// ReconcileWindow is how far back the measure groups are reconciled
+	// after syncing the body measures when the storage implements
+	// Reconciler. Zero disables it.
+	ReconcileWindow time.Duration
+}
+
+// Syncer syncs the data of users into a storage.
+type Syncer struct {
+	Storage Storage
+	Options Options
+}
+
+// New returns a syncer storing into the storage provided.
+func New(storage Storage, opts Options) *Syncer {
+	if len(opts.Types) == 0 {
+		opts.Types = DataTypes
+	}
+	if opts.Since.IsZero() {
+		opts.Since = time.Unix(0, 0)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Syncer{Storage: storage, Options: opts}
+}
+
+// Result is the outcome of syncing a data type.
+type Result struct {
+	Type DataType
+	// Count is the number of records upserted.
+	Count int
+	// Pages is the number of pages retrieved.
+	Pages      int
+	Checkpoint time.Time
+	// Events are the measure groups deleted or updated while syncing the
+	// body measures.
+	Events []MeasureGroupEvent
+}
+
+// Sync retrieves the changes of every configured data type for the user since
+// their checkpoint. It stops at the first error returning the results of the
+// data types already synced. The checkpoint of a data type is only saved once
+// every page of it has been stored.
+func (s *Syncer) Sync(ctx context.Context, userID int, api API) ([]Result, error) {
+	var results []Result
+	for _, t := range s.Options.Types {
+		r, err := s.SyncType(ctx, userID, api, t)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// SyncType retrieves the changes of a single data type for the user since its
+// checkpoint.
+func (s *Syncer) SyncType(ctx context.Context, userID int, api API, t DataType) (Result, error) {
+	r := Result{Type: t}
+
+	since, ok, err := s.Storage.Checkpoint(ctx, userID, t)
+	if err != nil {
+		return r, fmt.Errorf("failed to load %s checkpoint: %s", t, err)
+	}
+	if !ok {
+		since = s.Options.Since
+	}
+
+	// The checkpoint is taken before retrieving so changes made while
+	// syncing are picked up by the next sync.
+	r.Checkpoint = s.Options.Now()
+
+	switch t {
+	case BodyMeasures:
+		err = s.syncBodyMeasures(ctx, userID, api, since, &r)
+	case Activities:
+		err = s.syncActivities(ctx, userID, api, since, &r)
+	case Workouts:
+		err = s.syncWorkouts(ctx, userID, api, since, &r)
+	case SleepSummaries:
+		err = s.syncSleepSummaries(ctx, userID, api, since, &r)
+	default:
+		err = fmt.Errorf("unknown data type")
+	}
+	if err != nil {
+		return r, fmt.Errorf("failed to sync %s: %s", t, err)
+	}
+
+	if err := s.Storage.SaveCheckpoint(ctx, userID, t, r.Checkpoint); err != nil {
+		return r, fmt.Errorf("failed to save %s checkpoint: %s", t, err)
+	}
+	return r, nil
+}
+
+// errNoBody is returned when the API answers without a body so the data is
+// not mistaken for an empty page.
+var errNoBody = errors.New("api returned no body")
+
+// paginate calls fetch with the offset of every page until there are no more
+// pages. fetch returns if there are more pages and the offset of the next one.
+// An error is returned if the API asks for more pages without advancing the
+// offset so the checkpoint is not saved past pages that were not retrieved.
+func paginate(r *Result, fetch func(offset *int) (bool, int, error)) error {
+	var offset *int
+	for {
+		more, next, err := fetch(offset)
+		if err != nil {
+			return err
+		}
+		r.Pages++
+		if !more {
+			return nil
+		}
+		var prev int
+		if offset != nil {
+			prev = *offset
+		}
+		if next <= prev {
+			return fmt.Errorf("api did not advance past offset %d", prev)
+		}
+		offset = &next
+	}
+}
+
+func (s *Syncer) syncBodyMeasures(ctx context.Context, userID int, api API, since time.Time, r *Result) error {
+	now := r.Checkpoint
+	rec, _ := s.Storage.(Reconciler)
+
+	var updateTime int64
+	err := paginate(r, func(offset *int) (bool, int, error) {
+		resp, err := api.GetBodyMeasuresCtx(ctx, &nokiahealth.BodyMeasuresQueryParams{UserID: userID, LastUpdate: &since, Offset: offset})
+		if err != nil {
+			return false, 0, err
+		}
+		if resp.Body == nil {
+			return false, 0, errNoBody
+		}
+		if err := s.storeMeasureGroups(ctx, rec, userID, resp.Body.Timezone, resp.Body.MeasureGrps, r); err != nil {
+			return false, 0, err
+		}
+		r.Count += len(resp.Body.MeasureGrps)
+		if updateTime == 0 {
+			updateTime = resp.Body.Updatetime
+		}
+		return resp.Body.More != 0, resp.Body.Offset, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if rec != nil && s.Options.ReconcileWindow > 0 {
+		events, err := s.Reconcile(ctx, userID, api, now.Add(-s.Options.ReconcileWindow), now)
+		if err != nil {
+			return err
+		}
+		r.Events = append(r.Events, events...)
+	}
+
+	// Prefer the update time of the API so clock differences do not matter.
+	if updateTime != 0 {
+		r.Checkpoint = time.Unix(updateTime, 0)
+	}
+	return nil
+}
+
+// storeMeasureGroups upserts a page of measure groups. When the storage is a
+// reconciler, rec is not nil and the groups that changed since they were stored are applied as
+// update events instead.
+func (s *Syncer) storeMeasureGroups(ctx context.Context, rec Reconciler, userID int, timezone string, groups []nokiahealth.BodyMeasureGroupResp, r *Result) error {
+	if rec == nil {
+		return s.Storage.UpsertMeasureGroups(ctx, userID, timezone, groups)
+	}
+
+	events, err := updateEvents(ctx, rec, userID, timezone, groups)
+	if err != nil {
+		return err
+	}
+	updated := make(map[int]bool, len(events))
+	for _, e := range events {
+		updated[e.GrpID] = true
+	}
+	var others []nokiahealth.BodyMeasureGroupResp
+	for _, g := range groups {
+		if !updated[g.GrpID] {
+			others = append(others, g)
+		}
+	}
+
+	if len(others) > 0 {
+		if err := s.Storage.UpsertMeasureGroups(ctx, userID, timezone, others); err != nil {
+			return err
+		}
+	}
+	if len(events) > 0 {
+		if err := rec.ApplyMeasureGroupEvents(ctx, userID, events); err != nil {
+			return fmt.Errorf("failed to apply measure group events: %s", err)
+		}
+		r.Events = append(r.Events, events...)
+	}
+	return nil
+}
+
+func (s *Syncer) syncActivities(ctx context.Context, userID int, api API, since time.Time, r *Result) error {
+	return paginate(r, func(offset *int) (bool, int, error) {
+		resp, err := api.GetActivityMeasuresCtx(ctx, &nokiahealth.ActivityMeasuresQueryParam{UserID: userID, LasteUpdate: &since, Offset: offset})
+		if err != nil {
+			return false, 0, err
+		}
+		if resp.Body == nil {
+			return false, 0, errNoBody
+		}
+		activities := resp.Activities()
+		if err := s.Storage.UpsertActivities(ctx, userID, activities); err != nil {
+			return false, 0, err
+		}
+		r.Count += len(activities)
+		return resp.Body.More, resp.Body.Offset, nil
+	})
+}
+
+func (s *Syncer) syncWorkouts(ctx context.Context, userID int, api API, since time.Time, r *Result) error {
+	return paginate(r, func(offset *int) (bool, int, error) {
+		resp, err := api.GetWorkoutsCtx(ctx, &nokiahealth.WorkoutsQueryParam{UserID: userID, LastUpdate: &since, Offset: offset})
+		if err != nil {
+			return false, 0, err
+		}
+		if resp.Body == nil {
+			return false, 0, errNoBody
+		}
+		if err := s.Storage.UpsertWorkouts(ctx, userID, resp.Body.Series); err != nil {
+			return false, 0, err
+		}
+		r.Count += len(resp.Body.Series)
+		return resp.Body.More, resp.Body.Offset, nil
+	})
+}
+
+func (s *Syncer) syncSleepSummaries(ctx context.Context, userID int, api API, since time.Time, r *Result) error {
+	return paginate(r, func(offset *int) (bool, int, error) {
+		resp, err := api.GetSleepSummaryCtx(ctx, &nokiahealth.SleepSummaryQueryParam{LastUpdate: &since, Offset: offset})
+		if err != nil {
+			return false, 0, err
+		}
+		if resp.Body == nil {
+			return false, 0, errNoBody
+		}
+		if err := s.Storage.UpsertSleepSummaries(ctx, userID, resp.Body.Series); err != nil {
+			return false, 0, err
+		}
+		r.Count += len(resp.Body.Series)
+		return resp.Body.More, resp.Body.Offset, nil
+	})
+}
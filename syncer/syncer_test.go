@@ -0,0 +1,138 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// fakeAPI serves pages of data and records the last updates requested.
+type fakeAPI struct {
+	lastUpdates map[DataType][]int64
+	failSleep   bool
+	// stuck makes the workouts never advance the offset and noBody makes the
+	// activities return no body.
+	stuck  bool
+	noBody bool
+}
+
+func (f *fakeAPI) record(t DataType, lastUpdate int64) {
+	if f.lastUpdates == nil {
+		f.lastUpdates = map[DataType][]int64{}
+	}
+	f.lastUpdates[t] = append(f.lastUpdates[t], lastUpdate)
+}
+
+func (f *fakeAPI) GetBodyMeasuresCtx(ctx context.Context, p *nokiahealth.BodyMeasuresQueryParams) (nokiahealth.BodyMeasuresResp, error) {
+	f.record(BodyMeasures, p.LastUpdate.Unix())
+	body := &nokiahealth.BodyMeasureRespBody{Updatetime: 1600000000, Timezone: "Europe/Paris"}
+	if p.Offset == nil {
+		body.MeasureGrps = []nokiahealth.BodyMeasureGroupResp{{GrpID: 1, Date: 100}, {GrpID: 2, Date: 200}}
+		body.More, body.Offset = 1, 2
+	} else {
+		body.MeasureGrps = []nokiahealth.BodyMeasureGroupResp{{GrpID: 3, Date: 300}, {GrpID: 1, Date: 100}}
+	}
+	return nokiahealth.BodyMeasuresResp{Body: body}, nil
+}
+
+func (f *fakeAPI) GetActivityMeasuresCtx(ctx context.Context, p *nokiahealth.ActivityMeasuresQueryParam) (nokiahealth.ActivitiesMeasuresResp, error) {
+	f.record(Activities, p.LasteUpdate.Unix())
+	if f.noBody {
+		return nokiahealth.ActivitiesMeasuresResp{}, nil
+	}
+	return nokiahealth.ActivitiesMeasuresResp{Body: &nokiahealth.ActivitiesMeasuresRespBody{
+		Activities: []nokiahealth.Activity{{Date: "2018-07-01", Steps: 10}},
+	}}, nil
+}
+
+func (f *fakeAPI) GetWorkoutsCtx(ctx context.Context, p *nokiahealth.WorkoutsQueryParam) (nokiahealth.WorkoutResponse, error) {
+	f.record(Workouts, p.LastUpdate.Unix())
+	body := &nokiahealth.WorkoutRespBody{Series: []nokiahealth.Workout{{ID: 7}}}
+	if p.Offset == nil || f.stuck {
+		body.More, body.Offset = true, 1
+	}
+	return nokiahealth.WorkoutResponse{Body: body}, nil
+}
+
+func (f *fakeAPI) GetSleepSummaryCtx(ctx context.Context, p *nokiahealth.SleepSummaryQueryParam) (nokiahealth.SleepSummaryResp, error) {
+	f.record(SleepSummaries, p.LastUpdate.Unix())
+	if f.failSleep {
+		return nokiahealth.SleepSummaryResp{}, errors.New("boom")
+	}
+	return nokiahealth.SleepSummaryResp{Body: &nokiahealth.SleepSummaryBody{
+		Series: []nokiahealth.SleepSummary{{ID: 9}},
+	}}, nil
+}
+
+func TestSync(t *testing.T) {
+	storage := NewMemoryStorage()
+	now := time.Unix(1700000000, 0)
+	s := New(storage, Options{Now: func() time.Time { return now }})
+	api := &fakeAPI{}
+
+	results, err := s.Sync(context.Background(), 42, api)
+	if err != nil {
+		t.Fatalf("failed to sync: %s", err)
+	}
+	if len(results) != len(DataTypes) {
+		t.Fatalf("expected a result per data type got %+v", results)
+	}
+	if r := results[0]; r.Pages != 2 || r.Count != 4 || r.Checkpoint.Unix() != 1600000000 {
+		t.Errorf("unexpected body measures result %+v", r)
+	}
+	if r := results[2]; r.Pages != 2 || r.Count != 2 {
+		t.Errorf("unexpected workouts result %+v", r)
+	}
+	if groups := storage.MeasureGroups(42); len(groups) != 3 || groups[2].GrpID != 3 || groups[0].TimeZone != "Europe/Paris" {
+		t.Errorf("expected the groups to be upserted got %+v", groups)
+	}
+	if len(storage.Workouts(42)) != 1 || len(storage.Activities(42)) != 1 || len(storage.SleepSummaries(42)) != 1 {
+		t.Errorf("expected the records to be upserted once")
+	}
+	for _, typ := range DataTypes {
+		if api.lastUpdates[typ][0] != 0 {
+			t.Errorf("expected the first %s sync to start at the epoch got %d", typ, api.lastUpdates[typ][0])
+		}
+	}
+
+	// The second sync starts at the checkpoints.
+	api.lastUpdates = nil
+	api.failSleep = true
+	if _, err := s.Sync(context.Background(), 42, api); err == nil {
+		t.Fatalf("expected the sleep failure to be returned")
+	}
+	if api.lastUpdates[BodyMeasures][0] != 1600000000 || api.lastUpdates[Activities][0] != now.Unix() {
+		t.Errorf("expected the checkpoints to be used got %v", api.lastUpdates)
+	}
+	if c, ok, _ := storage.Checkpoint(context.Background(), 42, SleepSummaries); !ok || !c.Equal(now) {
+		t.Errorf("unexpected sleep checkpoint %s", c)
+	}
+	if _, ok, _ := storage.Checkpoint(context.Background(), 7, BodyMeasures); ok {
+		t.Errorf("expected checkpoints to be per user")
+	}
+}
+
+func TestSyncIncompletePages(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryStorage()
+	s := New(storage, Options{})
+
+	// A misbehaving API that never advances must neither loop forever nor
+	// save a checkpoint skipping the pages that were not retrieved.
+	if _, err := s.SyncType(ctx, 42, &fakeAPI{stuck: true}, Workouts); err == nil {
+		t.Errorf("expected an error when the offset does not advance")
+	}
+	if _, ok, _ := storage.Checkpoint(ctx, 42, Workouts); ok {
+		t.Errorf("expected no workouts checkpoint to be saved")
+	}
+
+	if _, err := s.SyncType(ctx, 42, &fakeAPI{noBody: true}, Activities); err == nil {
+		t.Errorf("expected an error when the response has no body")
+	}
+	if _, ok, _ := storage.Checkpoint(ctx, 42, Activities); ok {
+		t.Errorf("expected no activities checkpoint to be saved")
+	}
+}
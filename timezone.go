@@ -0,0 +1,64 @@
+package nokiahealth
+
+import (
+	"sync"
+	"time"
+)
+
+// Every parsed time is in the IANA time zone of the record it belongs to so
+// it renders as the user saw it. Call UTC on it for the instant in UTC and
+// CivilDate for the calendar day in the time zone of the record.
+
+// locations caches the time zones by name as loading one reads the time zone
+// database. Unknown names are cached as nil.
+var locations = struct {
+	sync.RWMutex
+	m map[string]*time.Location
+}{m: map[string]*time.Location{}}
+
+// LoadLocation returns the location of the IANA time zone name such as
+// Europe/Paris. Locations are cached after the first load. If the name is
+// empty or unknown UTC is returned along with false so callers can degrade
+// gracefully instead of failing.
+func LoadLocation(name string) (*time.Location, bool) {
+	locations.RLock()
+	loc, ok := locations.m[name]
+	locations.RUnlock()
+
+	if !ok {
+		l, err := time.LoadLocation(name)
+		if err != nil || name == "" {
+			l = nil
+		}
+		locations.Lock()
+		locations.m[name] = l
+		locations.Unlock()
+		loc = l
+	}
+
+	if loc == nil {
+		return time.UTC, false
+	}
+	return loc, true
+}
+
+// CivilDate returns the calendar day of the time in its own location in the
+// YYYY-MM-DD format used by the API.
+func CivilDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// ParseUnix returns the unix timestamp as a time in the time zone named. UTC
+// is used if the time zone is unknown.
+func ParseUnix(ts int64, tz string) time.Time {
+	loc, _ := LoadLocation(tz)
+	return time.Unix(ts, 0).In(loc)
+}
+
+// ParseCivilDate parses the YYYY-MM-DD date returned by the API as midnight
+// in the time zone named so the day never changes. UTC is used if the time
+// zone is unknown.
+func ParseCivilDate(date string, tz string) (time.Time, error) {
+	loc, _ := LoadLocation(tz)
+	return time.ParseInLocation("2006-01-02", date, loc)
+}
@@ -0,0 +1,39 @@
+package nokiahealth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadLocation(t *testing.T) {
+	loc, ok := LoadLocation("Europe/Paris")
+	if !ok || loc.String() != "Europe/Paris" {
+		t.Fatalf("failed to load Europe/Paris got %s", loc)
+	}
+	if again, _ := LoadLocation("Europe/Paris"); again != loc {
+		t.Errorf("expected the cached location to be returned")
+	}
+	for _, name := range []string{"", "Mars/Olympus_Mons"} {
+		if loc, ok := LoadLocation(name); ok || loc != time.UTC {
+			t.Errorf("expected %q to fall back to UTC got %s", name, loc)
+		}
+	}
+}
+
+func TestParseCivilDate(t *testing.T) {
+	d, err := ParseCivilDate("2018-07-01", "America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("failed to parse the date: %s", err)
+	}
+	if CivilDate(d) != "2018-07-01" || d.Hour() != 0 || d.UTC().Hour() != 7 {
+		t.Errorf("expected midnight of the same day in Los Angeles got %s", d)
+	}
+
+	ts := ParseUnix(1530428400, "Asia/Tokyo")
+	if ts.Location().String() != "Asia/Tokyo" || CivilDate(ts) != "2018-07-01" || CivilDate(ts.UTC()) != "2018-07-01" {
+		t.Errorf("unexpected unix time %s", ts)
+	}
+	if ts := ParseUnix(1530428400, "bogus"); ts.Location() != time.UTC {
+		t.Errorf("expected an unknown zone to fall back to UTC got %s", ts.Location())
+	}
+}
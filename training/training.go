@@ -0,0 +1,320 @@
+// Package training computes training insight from the workouts returned by the
+// Nokia Health API: weekly volume per workout type, heart rate based training
+// load and personal bests.
+package training
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/workouttype"
+)
+
+// Options configures the analysis. The zero value uses the defaults.
+type Options struct {
+	// RestingHR and MaxHR are used to compute the TRIMP from the average
+	// heart rate. Default to 60 and 190.
+	RestingHR float64
+	MaxHR     float64
+	// MinPaceDistance is the minimum distance in meters of a workout for it
+	// to be considered for the fastest pace. Defaults to 1000.
+	MinPaceDistance float64
+}
+
+func (o Options) withDefaults() Options {
+	if o.RestingHR <= 0 {
+		o.RestingHR = 60
+	}
+	if o.MaxHR <= o.RestingHR {
+		o.MaxHR = 190
+	}
+	if o.MinPaceDistance <= 0 {
+		o.MinPaceDistance = 1000
+	}
+	return o
+}
+
+// Session is a single workout with the values used by the analysis.
+type Session struct {
+	ID       int
+	Type     workouttype.WorkoutType
+	Start    time.Time
+	Duration time.Duration
+	// Distance is in meters and Calories in kilocalories.
+	Distance float64
+	Calories float64
+	// ZoneLoad is the time spent in each heart rate zone in minutes weighted
+	// by the zone, from 1 for the light zone to 4 for the peak zone.
+	// HasZoneLoad is false if the workout has no heart rate zones.
+	ZoneLoad    float64
+	HasZoneLoad bool
+	// TRIMP is the Banister training impulse computed from the average heart
+	// rate. HasTRIMP is false if the workout has no average heart rate. It is
+	// not on the same scale as ZoneLoad so they must not be combined.
+	TRIMP    float64
+	HasTRIMP bool
+}
+
+// Pace returns the time taken per kilometer. The second return value is false
+// if the workout has no distance.
+func (s Session) Pace() (time.Duration, bool) {
+	if s.Distance <= 0 || s.Duration <= 0 {
+		return 0, false
+	}
+	return time.Duration(float64(s.Duration) / (s.Distance / 1000)), true
+}
+
+// zoneWeights are the load weights of the time spent in each heart rate zone.
+var zoneWeights = [4]float64{1, 2, 3, 4}
+
+// NewSession extracts the values of a workout. The duration is the effective
+// duration if known or the time between the start and end date.
+func NewSession(w nokiahealth.Workout, opts Options) Session {
+	opts = opts.withDefaults()
+	s := Session{ID: w.ID, Start: nokiahealth.ParseUnix(w.StartDate, w.TimeZone)}
+	if w.Category != nil {
+		s.Type = *w.Category
+	}
+
+	d := w.Data
+	switch {
+	case d.EffectiveDuration != nil:
+		s.Duration = time.Duration(*d.EffectiveDuration * float64(time.Second))
+	case w.EndDate > w.StartDate:
+		s.Duration = time.Duration(w.EndDate-w.StartDate) * time.Second
+	}
+	switch {
+	case d.Distance != nil:
+		s.Distance = *d.Distance
+	case d.ManualDistance != nil:
+		s.Distance = *d.ManualDistance
+	}
+	switch {
+	case d.Calories != nil:
+		s.Calories = *d.Calories
+	case d.ManualCalories != nil:
+		s.Calories = *d.ManualCalories
+	}
+
+	zones := [4]*float64{d.HRZone0, d.HRZone1, d.HRZone2, d.HRZone3}
+	for i, z := range zones {
+		if z != nil {
+			s.ZoneLoad += *z / 60 * zoneWeights[i]
+			s.HasZoneLoad = true
+		}
+	}
+	if d.HRAverage != nil && s.Duration > 0 {
+		s.TRIMP = TRIMP(s.Duration, *d.HRAverage, opts.RestingHR, opts.MaxHR)
+		s.HasTRIMP = true
+	}
+
+	return s
+}
+
+// TRIMP returns the Banister training impulse of a workout of the duration
+// provided at the average heart rate.
+func TRIMP(duration time.Duration, avgHR float64, restingHR float64, maxHR float64) float64 {
+	reserve := (avgHR - restingHR) / (maxHR - restingHR)
+	if reserve <= 0 {
+		return 0
+	}
+	if reserve > 1 {
+		reserve = 1
+	}
+	return duration.Minutes() * reserve * 0.64 * math.Exp(1.92*reserve)
+}
+
+// Volume is the training volume of a workout type during a week.
+type Volume struct {
+	// Week is midnight of the Monday starting the week.
+	Week     time.Time
+	Type     workouttype.WorkoutType
+	Sessions int
+	Duration time.Duration
+	Distance float64
+	Calories float64
+	// ZoneLoad and TRIMP are the sums of the sessions that have them.
+	ZoneLoad float64
+	TRIMP    float64
+}
+
+// Record is a personal best set by a workout.
+type Record struct {
+	Type      workouttype.WorkoutType
+	WorkoutID int
+	Date      time.Time
+	Value     float64
+}
+
+// PersonalBests are the best values achieved per workout type.
+type PersonalBests struct {
+	LongestDistance map[workouttype.WorkoutType]Record
+	LongestDuration map[workouttype.WorkoutType]Record
+	MostCalories    map[workouttype.WorkoutType]Record
+	// FastestPace values are in seconds per kilometer.
+	FastestPace map[workouttype.WorkoutType]Record
+	// History lists every record in the order they were set including the
+	// ones that have since been beaten.
+	History []RecordEvent
+}
+
+// RecordKind identifies the kind of a personal best.
+type RecordKind int
+
+// RecordKind constants.
+const (
+	LongestDistance RecordKind = iota
+	LongestDuration
+	MostCalories
+	FastestPace
+)
+
+// String returns the name of the record kind.
+func (k RecordKind) String() string {
+	switch k {
+	case LongestDistance:
+		return "LongestDistance"
+	case LongestDuration:
+		return "LongestDuration"
+	case MostCalories:
+		return "MostCalories"
+	case FastestPace:
+		return "FastestPace"
+	}
+	return "RecordKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// RecordEvent is a personal best being set.
+type RecordEvent struct {
+	Kind     RecordKind
+	Record   Record
+	Previous *Record
+}
+
+// Report is the analysis of a set of workouts.
+type Report struct {
+	Sessions      []Session
+	Weekly        []Volume
+	PersonalBests PersonalBests
+}
+
+// Analyze computes the report for the workouts of the responses.
+func Analyze(responses []nokiahealth.WorkoutResponse, opts Options) Report {
+	var workouts []nokiahealth.Workout
+	for _, r := range responses {
+		if r.Body != nil {
+			workouts = append(workouts, r.Body.Series...)
+		}
+	}
+	return AnalyzeWorkouts(workouts, opts)
+}
+
+// AnalyzeWorkouts computes the report for the workouts provided. Workouts
+// found more than once are only counted once.
+func AnalyzeWorkouts(workouts []nokiahealth.Workout, opts Options) Report {
+	opts = opts.withDefaults()
+
+	seen := map[int]bool{}
+	r := Report{}
+	for _, w := range workouts {
+		if seen[w.ID] {
+			continue
+		}
+		seen[w.ID] = true
+		r.Sessions = append(r.Sessions, NewSession(w, opts))
+	}
+	sort.SliceStable(r.Sessions, func(i, j int) bool {
+		return r.Sessions[i].Start.Before(r.Sessions[j].Start)
+	})
+
+	r.Weekly = weekly(r.Sessions)
+	r.PersonalBests = personalBests(r.Sessions, opts)
+	return r
+}
+
+// weekStart returns the Monday starting the week of the time in its location.
+func weekStart(t time.Time) time.Time {
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
+
+// weekly groups the sessions by week and type.
+func weekly(sessions []Session) []Volume {
+	type key struct {
+		week time.Time
+		typ  workouttype.WorkoutType
+	}
+	byKey := map[key]*Volume{}
+	var volumes []*Volume
+	for _, s := range sessions {
+		k := key{weekStart(s.Start), s.Type}
+		v, ok := byKey[k]
+		if !ok {
+			v = &Volume{Week: k.week, Type: k.typ}
+			byKey[k] = v
+			volumes = append(volumes, v)
+		}
+		v.Sessions++
+		v.Duration += s.Duration
+		v.Distance += s.Distance
+		v.Calories += s.Calories
+		v.ZoneLoad += s.ZoneLoad
+		v.TRIMP += s.TRIMP
+	}
+
+	sort.SliceStable(volumes, func(i, j int) bool {
+		if !volumes[i].Week.Equal(volumes[j].Week) {
+			return volumes[i].Week.Before(volumes[j].Week)
+		}
+		return volumes[i].Type < volumes[j].Type
+	})
+	result := make([]Volume, len(volumes))
+	for i := range volumes {
+		result[i] = *volumes[i]
+	}
+	return result
+}
+
+// personalBests walks the sessions in order recording every new best.
+func personalBests(sessions []Session, opts Options) PersonalBests {
+	pb := PersonalBests{
+		LongestDistance: map[workouttype.WorkoutType]Record{},
+		LongestDuration: map[workouttype.WorkoutType]Record{},
+		MostCalories:    map[workouttype.WorkoutType]Record{},
+		FastestPace:     map[workouttype.WorkoutType]Record{},
+	}
+
+	check := func(kind RecordKind, bests map[workouttype.WorkoutType]Record, s Session, value float64, lower bool) {
+		rec := Record{Type: s.Type, WorkoutID: s.ID, Date: s.Start, Value: value}
+		prev, ok := bests[s.Type]
+		if ok && (lower && value >= prev.Value || !lower && value <= prev.Value) {
+			return
+		}
+		event := RecordEvent{Kind: kind, Record: rec}
+		if ok {
+			p := prev
+			event.Previous = &p
+		}
+		bests[s.Type] = rec
+		pb.History = append(pb.History, event)
+	}
+
+	for _, s := range sessions {
+		if s.Distance > 0 {
+			check(LongestDistance, pb.LongestDistance, s, s.Distance, false)
+		}
+		if s.Duration > 0 {
+			check(LongestDuration, pb.LongestDuration, s, s.Duration.Seconds(), false)
+		}
+		if s.Calories > 0 {
+			check(MostCalories, pb.MostCalories, s, s.Calories, false)
+		}
+		if pace, ok := s.Pace(); ok && s.Distance >= opts.MinPaceDistance {
+			check(FastestPace, pb.FastestPace, s, pace.Seconds(), true)
+		}
+	}
+	return pb
+}
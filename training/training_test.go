@@ -0,0 +1,82 @@
+package training
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+	"github.com/jrmycanady/nokiahealth/enum/workouttype"
+)
+
+func f(v float64) *float64 {
+	return &v
+}
+
+func workout(id int, typ workouttype.WorkoutType, start time.Time, data nokiahealth.WorkoutData) nokiahealth.Workout {
+	return nokiahealth.Workout{ID: id, Category: &typ, StartDate: start.Unix(), EndDate: start.Add(time.Hour).Unix(), TimeZone: "UTC", Data: data}
+}
+
+func TestAnalyze(t *testing.T) {
+	mon := time.Date(2018, 9, 3, 7, 0, 0, 0, time.UTC)
+	resp := nokiahealth.WorkoutResponse{
+		Body: &nokiahealth.WorkoutRespBody{
+			Series: []nokiahealth.Workout{
+				workout(1, workouttype.Run, mon, nokiahealth.WorkoutData{EffectiveDuration: f(1800), Distance: f(5000), Calories: f(300), HRZone1: f(600), HRZone2: f(1200)}),
+				workout(2, workouttype.Run, mon.AddDate(0, 0, 2), nokiahealth.WorkoutData{EffectiveDuration: f(3000), Distance: f(10000), Calories: f(600), HRAverage: f(155)}),
+				workout(3, workouttype.Swim, mon.AddDate(0, 0, 3), nokiahealth.WorkoutData{Calories: f(400)}),
+				workout(4, workouttype.Run, mon.AddDate(0, 0, 8), nokiahealth.WorkoutData{EffectiveDuration: f(1440), Distance: f(5000)}),
+			},
+		},
+	}
+
+	r := Analyze([]nokiahealth.WorkoutResponse{resp, resp}, Options{})
+
+	if len(r.Sessions) != 4 {
+		t.Fatalf("expected duplicated workouts to be ignored got %d sessions", len(r.Sessions))
+	}
+	if r.Sessions[0].ZoneLoad != 20+60 || !r.Sessions[0].HasZoneLoad || r.Sessions[0].HasTRIMP {
+		t.Errorf("unexpected zone load %+v", r.Sessions[0])
+	}
+	trimp := TRIMP(50*time.Minute, 155, 60, 190)
+	if math.Abs(r.Sessions[1].TRIMP-trimp) > 1e-9 || !r.Sessions[1].HasTRIMP || r.Sessions[1].HasZoneLoad {
+		t.Errorf("unexpected trimp %+v", r.Sessions[1])
+	}
+	if r.Sessions[2].HasZoneLoad || r.Sessions[2].HasTRIMP || r.Sessions[2].Duration != time.Hour {
+		t.Errorf("unexpected swim session %+v", r.Sessions[2])
+	}
+
+	if len(r.Weekly) != 3 {
+		t.Fatalf("expected 3 weekly volumes got %+v", r.Weekly)
+	}
+	w := r.Weekly[0]
+	if w.Type != workouttype.Run || w.Sessions != 2 || w.Distance != 15000 || w.Duration != 80*time.Minute || w.Calories != 900 {
+		t.Errorf("unexpected first week of runs %+v", w)
+	}
+	// The two load models are summed separately.
+	if w.ZoneLoad != 80 || math.Abs(w.TRIMP-trimp) > 1e-9 {
+		t.Errorf("unexpected weekly load %+v", w)
+	}
+
+	if s := RecordKind(7).String(); s != "RecordKind(7)" {
+		t.Errorf("unexpected unknown record kind %q", s)
+	}
+
+	pb := r.PersonalBests
+	if pb.LongestDistance[workouttype.Run].WorkoutID != 2 {
+		t.Errorf("unexpected longest run %+v", pb.LongestDistance[workouttype.Run])
+	}
+	fastest := pb.FastestPace[workouttype.Run]
+	if fastest.WorkoutID != 4 || fastest.Value != 288 {
+		t.Errorf("unexpected fastest pace %+v", fastest)
+	}
+	var paceEvents int
+	for _, e := range pb.History {
+		if e.Kind == FastestPace && e.Record.Type == workouttype.Run {
+			paceEvents++
+		}
+	}
+	if paceEvents != 3 {
+		t.Errorf("expected 3 pace records for runs got %d", paceEvents)
+	}
+}
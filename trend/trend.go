@@ -0,0 +1,247 @@
+// Package trend smooths noisy weight readings into a trend line and projects
+// when a target weight will be reached.
+//
+// Readings are first grouped per calendar day and averaged. The daily values
+// are then smoothed with an exponential moving average. Days without readings
+// are skipped and the smoothing factor is scaled by the length of the gap so
+// a week without weighing moves the trend as much as a week of daily readings
+// would have.
+package trend
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+// DefaultAlpha is the default daily smoothing factor. It keeps roughly the
+// last ten days of readings relevant to the trend.
+const DefaultAlpha = 0.1
+
+// DefaultRateWindow is the default window used to compute the rate of change.
+const DefaultRateWindow = 28 * 24 * time.Hour
+
+// ErrNotEnoughData is returned when there are too few readings to compute the
+// requested value.
+var ErrNotEnoughData = errors.New("not enough data")
+
+// ErrTargetUnreachable is returned when the trend is moving away from the
+// target or is flat.
+var ErrTargetUnreachable = errors.New("target is not reachable at the current rate")
+
+// Options configures the trend analysis. The zero value uses the defaults.
+type Options struct {
+	// Alpha is the daily smoothing factor between 0 and 1. Higher values
+	// follow the readings more closely.
+	Alpha float64
+	// Location is used to decide which day a reading belongs to. Defaults to
+	// time.Local.
+	Location *time.Location
+	// RateWindow is how far back from the last point the rate of change is
+	// computed over.
+	RateWindow time.Duration
+}
+
+func (o Options) alpha() float64 {
+	if o.Alpha <= 0 || o.Alpha > 1 {
+		return DefaultAlpha
+	}
+	return o.Alpha
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.Local
+	}
+	return o.Location
+}
+
+func (o Options) rateWindow() time.Duration {
+	if o.RateWindow <= 0 {
+		return DefaultRateWindow
+	}
+	return o.RateWindow
+}
+
+// Point is the trend for a single day with readings.
+type Point struct {
+	// Date is midnight of the day in the configured location.
+	Date time.Time
+	// Weight is the mean of the readings of the day in kg.
+	Weight float64
+	// Readings is the number of readings taken during the day.
+	Readings int
+	// Trend is the smoothed weight in kg.
+	Trend float64
+}
+
+// Trend is a smoothed weight series.
+type Trend struct {
+	Points  []Point
+	options Options
+}
+
+// Analyze computes the trend of the weights provided. The weights do not need
+// to be sorted.
+func Analyze(weights []nokiahealth.Weight, opts Options) Trend {
+	loc := opts.location()
+
+	// Average the readings per day.
+	type day struct {
+		sum   float64
+		count int
+	}
+	days := map[time.Time]*day{}
+	for _, w := range weights {
+		t := w.Date.In(loc)
+		d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		if _, ok := days[d]; !ok {
+			days[d] = &day{}
+		}
+		days[d].sum += w.Kgs
+		days[d].count++
+	}
+
+	tr := Trend{options: opts}
+	for d, v := range days {
+		tr.Points = append(tr.Points, Point{Date: d, Weight: v.sum / float64(v.count), Readings: v.count})
+	}
+	sort.Slice(tr.Points, func(i, j int) bool {
+		return tr.Points[i].Date.Before(tr.Points[j].Date)
+	})
+
+	// Smooth the daily values scaling the factor by the gap in days.
+	alpha := opts.alpha()
+	for i := range tr.Points {
+		if i == 0 {
+			tr.Points[i].Trend = tr.Points[i].Weight
+			continue
+		}
+		gap := daysBetween(tr.Points[i-1].Date, tr.Points[i].Date)
+		a := 1 - math.Pow(1-alpha, gap)
+		tr.Points[i].Trend = tr.Points[i-1].Trend + a*(tr.Points[i].Weight-tr.Points[i-1].Trend)
+	}
+
+	return tr
+}
+
+// daysBetween returns the number of calendar days between a and b. Daylight
+// saving changes are rounded away.
+func daysBetween(a time.Time, b time.Time) float64 {
+	return math.Round(b.Sub(a).Hours() / 24)
+}
+
+// Last returns the last point of the trend.
+func (t Trend) Last() (Point, bool) {
+	if len(t.Points) == 0 {
+		return Point{}, false
+	}
+	return t.Points[len(t.Points)-1], true
+}
+
+// WeeklyRate returns the rate of change of the trend in kg per week. It is the
+// least squares slope of the trend over the rate window ending at the last
+// point. Negative values mean the weight is going down.
+func (t Trend) WeeklyRate() (float64, error) {
+	last, ok := t.Last()
+	if !ok {
+		return 0, ErrNotEnoughData
+	}
+	start := last.Date.Add(-t.options.rateWindow())
+
+	var n, sumX, sumY, sumXY, sumXX float64
+	for _, p := range t.Points {
+		if p.Date.Before(start) {
+			continue
+		}
+		x := daysBetween(last.Date, p.Date)
+		n++
+		sumX += x
+		sumY += p.Trend
+		sumXY += x * p.Trend
+		sumXX += x * x
+	}
+
+	denom := n*sumXX - sumX*sumX
+	if n < 2 || denom == 0 {
+		return 0, ErrNotEnoughData
+	}
+	return (n*sumXY - sumX*sumY) / denom * 7, nil
+}
+
+// WeeklyRates returns the rate of change at each point computed over the rate
+// window ending at that point. Points without enough history are omitted.
+func (t Trend) WeeklyRates() []Rate {
+	var rates []Rate
+	for i := range t.Points {
+		sub := Trend{Points: t.Points[:i+1], options: t.options}
+		r, err := sub.WeeklyRate()
+		if err != nil {
+			continue
+		}
+		rates = append(rates, Rate{Date: t.Points[i].Date, KgsPerWeek: r})
+	}
+	return rates
+}
+
+// Rate is the weekly rate of change of the trend at a date.
+type Rate struct {
+	Date       time.Time
+	KgsPerWeek float64
+}
+
+// Projection is the projected date a target weight will be reached.
+type Projection struct {
+	Target     float64
+	Date       time.Time
+	KgsPerWeek float64
+}
+
+// Project returns when the trend will reach the target weight at the current
+// weekly rate. If the last point is at the target, or went past it on the
+// last day while moving at the current rate, the target is reached and the
+// date of the last point is returned. ErrTargetUnreachable is returned if the
+// trend is moving away from the target, including after it went past it.
+func (t Trend) Project(target float64) (Projection, error) {
+	last, ok := t.Last()
+	if !ok {
+		return Projection{}, ErrNotEnoughData
+	}
+	remaining := target - last.Trend
+	if math.Abs(remaining) < 1e-9 {
+		return Projection{Target: target, Date: last.Date}, nil
+	}
+
+	rate, err := t.WeeklyRate()
+	if err != nil {
+		return Projection{}, err
+	}
+	if rate != 0 && t.crossed(target, rate) {
+		return Projection{Target: target, Date: last.Date, KgsPerWeek: rate}, nil
+	}
+	if rate == 0 || math.Signbit(rate) != math.Signbit(remaining) {
+		return Projection{}, ErrTargetUnreachable
+	}
+
+	days := int(math.Ceil(remaining/rate*7 - 1e-9))
+	return Projection{Target: target, Date: last.Date.AddDate(0, 0, days), KgsPerWeek: rate}, nil
+}
+
+// crossed returns true if the last point went past the target in the
+// direction of the rate. The point before it must be at or before the target
+// so a trend that reached the target earlier and kept going is moving away
+// from it rather than reaching it.
+func (t Trend) crossed(target float64, rate float64) bool {
+	n := len(t.Points)
+	if n < 2 {
+		return false
+	}
+	prev, last := t.Points[n-2].Trend, t.Points[n-1].Trend
+	if rate < 0 {
+		return prev >= target && last < target
+	}
+	return prev <= target && last > target
+}
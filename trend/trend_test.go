@@ -0,0 +1,95 @@
+package trend
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jrmycanady/nokiahealth"
+)
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2018, 3, 1, 7, 0, 0, 0, time.UTC)
+	weights := []nokiahealth.Weight{
+		{Date: start, Kgs: 80},
+		{Date: start.Add(12 * time.Hour), Kgs: 82},
+		{Date: start.AddDate(0, 0, 3), Kgs: 79},
+	}
+
+	tr := Analyze(weights, Options{Alpha: 0.5, Location: time.UTC})
+
+	if len(tr.Points) != 2 {
+		t.Fatalf("expected 2 days got %d", len(tr.Points))
+	}
+	if tr.Points[0].Weight != 81 || tr.Points[0].Readings != 2 || tr.Points[0].Trend != 81 {
+		t.Errorf("unexpected first point %+v", tr.Points[0])
+	}
+
+	// A three day gap applies 1 - 0.5^3 of the difference.
+	want := 81 + 0.875*(79-81)
+	if math.Abs(tr.Points[1].Trend-want) > 1e-9 {
+		t.Errorf("expected trend %f got %f", want, tr.Points[1].Trend)
+	}
+}
+
+func TestWeeklyRateAndProjection(t *testing.T) {
+	start := time.Date(2018, 3, 1, 7, 0, 0, 0, time.UTC)
+	var weights []nokiahealth.Weight
+	for i := 0; i < 28; i++ {
+		weights = append(weights, nokiahealth.Weight{Date: start.AddDate(0, 0, i), Kgs: 90 - float64(i)/7})
+	}
+
+	tr := Analyze(weights, Options{Alpha: 1, Location: time.UTC})
+
+	rate, err := tr.WeeklyRate()
+	if err != nil {
+		t.Fatalf("failed to compute rate: %s", err)
+	}
+	if math.Abs(rate+1) > 1e-9 {
+		t.Errorf("expected -1 kg per week got %f", rate)
+	}
+
+	p, err := tr.Project(84)
+	if err != nil {
+		t.Fatalf("failed to project: %s", err)
+	}
+	last, _ := tr.Last()
+	if !p.Date.Equal(last.Date.AddDate(0, 0, 15)) {
+		t.Errorf("expected projection 15 days after %s got %s", last.Date, p.Date)
+	}
+
+	if _, err := tr.Project(100); err != ErrTargetUnreachable {
+		t.Errorf("expected unreachable target got %v", err)
+	}
+
+	// The trend went past 86.2 on the last day.
+	p, err = tr.Project(86.2)
+	if err != nil {
+		t.Fatalf("expected an overshot target to be reached got %s", err)
+	}
+	if !p.Date.Equal(last.Date) {
+		t.Errorf("expected the target reached on %s got %s", last.Date, p.Date)
+	}
+
+	// The trend went past 88.5 on the 12th day and kept falling away from it.
+	if _, err := tr.Project(88.5); err != ErrTargetUnreachable {
+		t.Errorf("expected a target left behind to be unreachable got %v", err)
+	}
+}
+
+func TestProjectMovingAway(t *testing.T) {
+	start := time.Date(2018, 1, 1, 7, 0, 0, 0, time.UTC)
+	var weights []nokiahealth.Weight
+	for i := 0; i < 60; i++ {
+		weights = append(weights, nokiahealth.Weight{Date: start.AddDate(0, 0, i), Kgs: 74 + float64(i)/10})
+	}
+	tr := Analyze(weights, Options{Location: time.UTC})
+
+	// The trend crossed 75 on the way up and is still rising.
+	if last, _ := tr.Last(); last.Trend <= 75 || tr.Points[0].Trend >= 75 {
+		t.Fatalf("expected the trend to cross 75 got %f to %f", tr.Points[0].Trend, last.Trend)
+	}
+	if p, err := tr.Project(75); err != ErrTargetUnreachable {
+		t.Errorf("expected the target to be unreachable got %+v %v", p, err)
+	}
+}
@@ -1,17 +1,23 @@
 package nokiahealth
 
 import (
+	"encoding/json"
 	"math"
 	"net/url"
 	"reflect"
+	"sort"
 	"time"
 
+	"github.com/jrmycanady/nokiahealth/enum/afib"
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+	"github.com/jrmycanady/nokiahealth/enum/category"
 	"github.com/jrmycanady/nokiahealth/enum/meastype"
 	"github.com/jrmycanady/nokiahealth/enum/sleepstate"
 
 	"github.com/jrmycanady/nokiahealth/enum/devtype"
 	"github.com/jrmycanady/nokiahealth/enum/status"
 
+	"github.com/jrmycanady/nokiahealth/enum/wearposition"
 	"github.com/jrmycanady/nokiahealth/enum/workouttype"
 )
 
@@ -112,22 +118,89 @@ type CreateNotificationResp struct {
 // SleepSummaryQueryParam provides the query parameters for requests of sleep
 // summary data. A date must be specified either with the StartDateYMD/EndDateYMD pair or
 // setting the LastUpdate.
-// The LastUpdate allow setting to zero for the first call so a time.Time struct
-// is not accepted but rather the raw UNIX time.
+// The LastUpdate can be set to the UNIX epoch, time.Unix(0, 0), for the first
+// call to retrieve every summary.
+// DataFields selects the fields returned in the summary data. If empty the
+// API decides which fields are returned.
 type SleepSummaryQueryParam struct {
-	StartDateYMD *time.Time `json:"startdateymd"`
-	EndDateYMD   *time.Time `json:"enddateymd"`
-	LastUpdate   *int64     `json:"lastupdate"`
-	Offset       *int       `json:"offset"`
+	StartDateYMD *time.Time          `json:"startdateymd"`
+	EndDateYMD   *time.Time          `json:"enddateymd"`
+	LastUpdate   *time.Time          `json:"lastupdate"`
+	Offset       *int                `json:"offset"`
+	DataFields   []SleepSummaryField `json:"data_fields"`
+}
+
+// SleepSummaryField is a data field that can be requested from the sleep
+// summary via SleepSummaryQueryParam.DataFields.
+type SleepSummaryField string
+
+// SleepSummaryField constants for the nokia health api.
+const (
+	SleepSummaryFieldBreathingDisturbancesIntensity SleepSummaryField = "breathing_disturbances_intensity"
+	SleepSummaryFieldDeepSleepDuration              SleepSummaryField = "deepsleepduration"
+	SleepSummaryFieldDurationToSleep                SleepSummaryField = "durationtosleep"
+	SleepSummaryFieldDurationToWakeUp               SleepSummaryField = "durationtowakeup"
+	SleepSummaryFieldHRAverage                      SleepSummaryField = "hr_average"
+	SleepSummaryFieldHRMax                          SleepSummaryField = "hr_max"
+	SleepSummaryFieldHRMin                          SleepSummaryField = "hr_min"
+	SleepSummaryFieldLightSleepDuration             SleepSummaryField = "lightsleepduration"
+	SleepSummaryFieldREMSleepDuration               SleepSummaryField = "remsleepduration"
+	SleepSummaryFieldRRAverage                      SleepSummaryField = "rr_average"
+	SleepSummaryFieldRRMax                          SleepSummaryField = "rr_max"
+	SleepSummaryFieldRRMin                          SleepSummaryField = "rr_min"
+	SleepSummaryFieldSleepScore                     SleepSummaryField = "sleep_score"
+	SleepSummaryFieldSnoring                        SleepSummaryField = "snoring"
+	SleepSummaryFieldSnoringEpisodeCount            SleepSummaryField = "snoringepisodecount"
+	SleepSummaryFieldWakeUpCount                    SleepSummaryField = "wakeupcount"
+	SleepSummaryFieldWakeUpDuration                 SleepSummaryField = "wakeupduration"
+	SleepSummaryFieldNightEvents                    SleepSummaryField = "night_events"
+)
+
+// SleepSummaryFields lists every SleepSummaryField.
+var SleepSummaryFields = []SleepSummaryField{
+	SleepSummaryFieldBreathingDisturbancesIntensity,
+	SleepSummaryFieldDeepSleepDuration,
+	SleepSummaryFieldDurationToSleep,
+	SleepSummaryFieldDurationToWakeUp,
+	SleepSummaryFieldHRAverage,
+	SleepSummaryFieldHRMax,
+	SleepSummaryFieldHRMin,
+	SleepSummaryFieldLightSleepDuration,
+	SleepSummaryFieldREMSleepDuration,
+	SleepSummaryFieldRRAverage,
+	SleepSummaryFieldRRMax,
+	SleepSummaryFieldRRMin,
+	SleepSummaryFieldSleepScore,
+	SleepSummaryFieldSnoring,
+	SleepSummaryFieldSnoringEpisodeCount,
+	SleepSummaryFieldWakeUpCount,
+	SleepSummaryFieldWakeUpDuration,
+	SleepSummaryFieldNightEvents,
 }
 
 // SleepMeasuresQueryParam acts as the config parameter for sleep measures requests.
+// DataFields selects the high frequency series returned with each state.
 type SleepMeasuresQueryParam struct {
-	UserID    int       `json:"userid"`
-	StartDate time.Time `json:"startdate"`
-	EndDate   time.Time `json:"enddate"`
+	UserID     int                 `json:"userid"`
+	StartDate  time.Time           `json:"startdate"`
+	EndDate    time.Time           `json:"enddate"`
+	DataFields []SleepMeasureField `json:"data_fields"`
+	// Location is the time zone the dates of the measures are parsed in as
+	// the API does not return it. Defaults to time.Local.
+	Location *time.Location `json:"-"`
 }
 
+// SleepMeasureField is a high frequency data field that can be requested with
+// the sleep measures via SleepMeasuresQueryParam.DataFields.
+type SleepMeasureField string
+
+// SleepMeasureField constants for the nokia health api.
+const (
+	SleepMeasureFieldHR      SleepMeasureField = "hr"
+	SleepMeasureFieldRR      SleepMeasureField = "rr"
+	SleepMeasureFieldSnoring SleepMeasureField = "snoring"
+)
+
 // SleepSummaryResp represents the unmarshelled api response for sleep summary.
 type SleepSummaryResp struct {
 	Status      status.Status     `json:"status"`
@@ -138,9 +211,11 @@ type SleepSummaryResp struct {
 }
 
 // SleepSummaryBody represents the unmarshelled api response for the sleep summary body.
+// If More is true there are more summaries to retrieve starting at Offset.
 type SleepSummaryBody struct {
 	Series []SleepSummary `json:"series"`
 	More   bool           `json:"more"`
+	Offset int            `json:"offset"`
 }
 
 // SleepSummary is a summary of one sleep entry.
@@ -158,16 +233,30 @@ type SleepSummary struct {
 	DateParsed      *time.Time       `json:"dateparsed"`
 }
 
-// SleepSummaryData contains the summary data for the sleep summary. Not all fields are required
-// so some are pointers and can be nil.
+// SleepSummaryData contains the summary data for the sleep summary. Which fields
+// are returned depends on the device and the data fields requested so all of
+// them are pointers and can be nil. Durations are in seconds, heart rates in
+// beats per minute and respiration rates in breaths per minute.
+// NightEvents is left raw as the format is not documented by the API.
 type SleepSummaryData struct {
-	WakeUpDuration     int  `json:"wakeupduration"`
-	LightSleepDuration int  `json:"lightsleepduration"`
-	DeepSleepDuration  int  `json:"deepsleepduration"`
-	REMSleepDuration   *int `json:"remsleepduration"`
-	WakeUpCount        int  `json:"wakeupcount"`
-	DurationToSleep    int  `json:"durationtosleep"`
-	DurationToWakeUp   *int `json:"durationtowakeup"`
+	WakeUpDuration                 *int            `json:"wakeupduration"`
+	LightSleepDuration             *int            `json:"lightsleepduration"`
+	DeepSleepDuration              *int            `json:"deepsleepduration"`
+	REMSleepDuration               *int            `json:"remsleepduration"`
+	WakeUpCount                    *int            `json:"wakeupcount"`
+	DurationToSleep                *int            `json:"durationtosleep"`
+	DurationToWakeUp               *int            `json:"durationtowakeup"`
+	HRAverage                      *float64        `json:"hr_average"`
+	HRMin                          *float64        `json:"hr_min"`
+	HRMax                          *float64        `json:"hr_max"`
+	RRAverage                      *float64        `json:"rr_average"`
+	RRMin                          *float64        `json:"rr_min"`
+	RRMax                          *float64        `json:"rr_max"`
+	BreathingDisturbancesIntensity *float64        `json:"breathing_disturbances_intensity"`
+	Snoring                        *int            `json:"snoring"`
+	SnoringEpisodeCount            *int            `json:"snoringepisodecount"`
+	SleepScore                     *int            `json:"sleep_score"`
+	NightEvents                    json.RawMessage `json:"night_events"`
 }
 
 // SleepMeasuresResp represents the unmarshelled api response for sleep measures.
@@ -185,20 +274,114 @@ type SleepMeasuresRespBody struct {
 	Model  int            `json:"model"`
 }
 
-// SleepMeasure is a specific instance of sleep returned by the API.
+// SleepMeasure is a specific instance of sleep returned by the API. The HR, RR
+// and Snoring maps are only populated when requested via the data fields and
+// are keyed by UNIX timestamp.
 type SleepMeasure struct {
 	StartDate       int64                 `json:"startdate"`
 	EndDate         int64                 `json:"enddate"`
 	State           sleepstate.SleepState `json:"state"`
+	HR              map[int64]float64     `json:"hr"`
+	RR              map[int64]float64     `json:"rr"`
+	Snoring         map[int64]float64     `json:"snoring"`
 	StartDateParsed *time.Time            `json:"startdateparsed"`
-	EndDateParsed   *time.Time            `'json:"enddateparsed"`
+	EndDateParsed   *time.Time            `json:"enddateparsed"`
+}
+
+// SleepSample is a single value of a sleep high frequency series.
+type SleepSample struct {
+	Date  time.Time
+	Value float64
+}
+
+// HeartRate returns the heart rate samples of all the sleep measures in beats
+// per minute ordered by date.
+func (rm SleepMeasuresResp) HeartRate() []SleepSample {
+	return rm.samples(func(m SleepMeasure) map[int64]float64 { return m.HR })
+}
+
+// RespirationRate returns the respiration rate samples of all the sleep
+// measures in breaths per minute ordered by date.
+func (rm SleepMeasuresResp) RespirationRate() []SleepSample {
+	return rm.samples(func(m SleepMeasure) map[int64]float64 { return m.RR })
+}
+
+// Snoring returns the snoring samples of all the sleep measures ordered by
+// date.
+func (rm SleepMeasuresResp) Snoring() []SleepSample {
+	return rm.samples(func(m SleepMeasure) map[int64]float64 { return m.Snoring })
+}
+
+// samples merges the series selected from each measure. Timestamps found in
+// more than one measure are only included once. The dates are in the location
+// the measures were parsed in like the hypnogram.
+func (rm SleepMeasuresResp) samples(series func(m SleepMeasure) map[int64]float64) []SleepSample {
+	var samples []SleepSample
+	if rm.Body == nil {
+		return samples
+	}
+
+	values := map[int64]float64{}
+	for _, m := range rm.Body.Series {
+		for ts, v := range series(m) {
+			values[ts] = v
+		}
+	}
+
+	timestamps := make([]int64, 0, len(values))
+	for ts := range values {
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	loc := rm.location()
+	for _, ts := range timestamps {
+		samples = append(samples, SleepSample{Date: time.Unix(ts, 0).In(loc), Value: values[ts]})
+	}
+	return samples
 }
 
 // IntradayActivityQueryParam acts as the config parameter for intraday activity retrieval requests.
+// DataFields selects the fields returned for each intraday activity. The API
+// returns its default set when it is empty.
 type IntradayActivityQueryParam struct {
-	UserID    int        `json:"userid"`
-	StartDate *time.Time `json:"startdate"`
-	EndDate   *time.Time `json:"enddate"`
+	UserID     int             `json:"userid"`
+	StartDate  *time.Time      `json:"startdate"`
+	EndDate    *time.Time      `json:"enddate"`
+	DataFields []IntradayField `json:"data_fields"`
+	// Location is the time zone the dates of the samples are in as the API
+	// does not return it. Defaults to time.Local.
+	Location *time.Location `json:"-"`
+}
+
+// IntradayField is a data field that can be requested with the intraday
+// activity via IntradayActivityQueryParam.DataFields.
+type IntradayField string
+
+// IntradayField constants for the nokia health api.
+const (
+	IntradayFieldSteps     IntradayField = "steps"
+	IntradayFieldElevation IntradayField = "elevation"
+	IntradayFieldCalories  IntradayField = "calories"
+	IntradayFieldDistance  IntradayField = "distance"
+	IntradayFieldStroke    IntradayField = "stroke"
+	IntradayFieldPoolLap   IntradayField = "pool_lap"
+	IntradayFieldDuration  IntradayField = "duration"
+	IntradayFieldHeartRate IntradayField = "heart_rate"
+	IntradayFieldSpO2Auto  IntradayField = "spo2_auto"
+)
+
+// IntradayFields lists every intraday data field.
+var IntradayFields = []IntradayField{
+	IntradayFieldSteps,
+	IntradayFieldElevation,
+	IntradayFieldCalories,
+	IntradayFieldDistance,
+	IntradayFieldStroke,
+	IntradayFieldPoolLap,
+	IntradayFieldDuration,
+	IntradayFieldHeartRate,
+	IntradayFieldSpO2Auto,
 }
 
 // IntradayActivityResp represents the unmarshelled api response for intraday activities.
@@ -208,6 +391,9 @@ type IntradayActivityResp struct {
 	Body        *IntradayActivityRespBody `json:"body"`
 	RawResponse []byte
 	Path        string
+	// Location is the time zone of the samples taken from the
+	// IntradayActivityQueryParam.Location of the request.
+	Location *time.Location `json:"-"`
 }
 
 // IntradayActivityRespBody represents the unmarshelled api response body for intraday activities.
@@ -216,8 +402,9 @@ type IntradayActivityRespBody struct {
 }
 
 // IntraDayActivity represents an intra day activity as returned by the API.
-// Their is likey work to be done here as the documentation does not provide
-// musch information reegarding what paramters it should contain.
+// Only the fields requested via the data fields are set. HeartRate is in beats
+// per minute and SpO2Auto is the automatic blood oxygen saturation in percent.
+// DeviceID and Model identify the device that recorded the activity.
 type IntraDayActivity struct {
 	Calories  *float64 `json:"calories"`
 	Distance  *float64 `json:"distance"`
@@ -225,15 +412,58 @@ type IntraDayActivity struct {
 	Elevation *float64 `json:"elevation"`
 	Steps     *int     `json:"steps"`
 	PoolLap   *int     `json:"pool_lap"`
+	Stroke    *int     `json:"stroke"`
+	HeartRate *int     `json:"heart_rate"`
+	SpO2Auto  *float64 `json:"spo2_auto"`
+	DeviceID  *string  `json:"deviceid"`
+	Model     *string  `json:"model"`
+	ModelID   *int     `json:"model_id"`
 }
 
 // WorkoutsQueryParam acts as the config parameter for workout retrieval requests.
+// LastUpdate can be used instead of the date range to retrieve the workouts
+// modified since then. Offset is used to request the next page when the
+// previous response had More set. DataFields selects the fields returned in
+// the workout data.
 type WorkoutsQueryParam struct {
-	UserID       int        `json:"userid"`
-	StartDateYMD *time.Time `json:"startdateymd"`
-	EndDateYMD   *time.Time `json:"enddateymd"`
+	UserID       int            `json:"userid"`
+	StartDateYMD *time.Time     `json:"startdateymd"`
+	EndDateYMD   *time.Time     `json:"enddateymd"`
+	LastUpdate   *time.Time     `json:"lastupdate"`
+	Offset       *int           `json:"offset"`
+	DataFields   []WorkoutField `json:"data_fields"`
 }
 
+// WorkoutField is a data field that can be requested with the workouts via
+// WorkoutsQueryParam.DataFields.
+type WorkoutField string
+
+// WorkoutField constants for the nokia health api.
+const (
+	WorkoutFieldCalories          WorkoutField = "calories"
+	WorkoutFieldEffectiveDuration WorkoutField = "effduration"
+	WorkoutFieldIntensity         WorkoutField = "intensity"
+	WorkoutFieldManualDistance    WorkoutField = "manual_distance"
+	WorkoutFieldManualCalories    WorkoutField = "manual_calories"
+	WorkoutFieldDistance          WorkoutField = "distance"
+	WorkoutFieldSteps             WorkoutField = "steps"
+	WorkoutFieldElevation         WorkoutField = "elevation"
+	WorkoutFieldMetCumul          WorkoutField = "metcumul"
+	WorkoutFieldPoolLaps          WorkoutField = "pool_laps"
+	WorkoutFieldPoolLength        WorkoutField = "pool_length"
+	WorkoutFieldStrokes           WorkoutField = "strokes"
+	WorkoutFieldHRAverage         WorkoutField = "hr_average"
+	WorkoutFieldHRMin             WorkoutField = "hr_min"
+	WorkoutFieldHRMax             WorkoutField = "hr_max"
+	WorkoutFieldHRZone0           WorkoutField = "hr_zone_0"
+	WorkoutFieldHRZone1           WorkoutField = "hr_zone_1"
+	WorkoutFieldHRZone2           WorkoutField = "hr_zone_2"
+	WorkoutFieldHRZone3           WorkoutField = "hr_zone_3"
+	WorkoutFieldPauseDuration     WorkoutField = "pause_duration"
+	WorkoutFieldAlgoPauseDuration WorkoutField = "algo_pause_duration"
+	WorkoutFieldSpO2Average       WorkoutField = "spo2_average"
+)
+
 // WorkoutResponse represents the unmarshelled api response for workouts.
 type WorkoutResponse struct {
 	Status      status.Status    `json:"status"`
@@ -244,8 +474,11 @@ type WorkoutResponse struct {
 }
 
 // WorkoutRespBody represents the unmarshelled body of the workout api resposne.
+// If More is true there are more workouts to retrieve starting at Offset.
 type WorkoutRespBody struct {
 	Series []Workout `json:"series"`
+	More   bool      `json:"more"`
+	Offset int       `json:"offset"`
 }
 
 // Workout contains each workout entry as returned by the API. The raw dates are provided
@@ -262,12 +495,112 @@ type Workout struct {
 	Date            string                   `json:"date"`
 	TimeZone        string                   `json:"timezone"`
 	Modified        int                      `json:"modified"`
-	Data            map[string]float64       `json:"data"`
+	Data            WorkoutData              `json:"data"`
 	StartDateParsed *time.Time               `json:"startdateparsed"`
 	EndDateParsed   *time.Time               `json:"enddateparsed"`
 	DateParsed      *time.Time               `json:"dateparsed"`
 }
 
+// WorkoutData contains the data of a workout. Which fields are returned depends
+// on the workout category and device so all of them are pointers and can be nil.
+// Durations are in seconds, distances and elevation in meters, energy in
+// kilocalories and heart rates in beats per minute. The heart rate zones are
+// the seconds spent in each zone. Any field returned by the API that is not
+// modeled is kept in Extras.
+type WorkoutData struct {
+	Calories          *float64 `json:"calories"`
+	EffectiveDuration *float64 `json:"effduration"`
+	Intensity         *float64 `json:"intensity"`
+	ManualDistance    *float64 `json:"manual_distance"`
+	ManualCalories    *float64 `json:"manual_calories"`
+	Distance          *float64 `json:"distance"`
+	Steps             *float64 `json:"steps"`
+	Elevation         *float64 `json:"elevation"`
+	MetCumul          *float64 `json:"metcumul"`
+	PoolLaps          *float64 `json:"pool_laps"`
+	PoolLength        *float64 `json:"pool_length"`
+	Strokes           *float64 `json:"strokes"`
+	HRAverage         *float64 `json:"hr_average"`
+	HRMin             *float64 `json:"hr_min"`
+	HRMax             *float64 `json:"hr_max"`
+	HRZone0           *float64 `json:"hr_zone_0"`
+	HRZone1           *float64 `json:"hr_zone_1"`
+	HRZone2           *float64 `json:"hr_zone_2"`
+	HRZone3           *float64 `json:"hr_zone_3"`
+	PauseDuration     *float64 `json:"pause_duration"`
+	AlgoPauseDuration *float64 `json:"algo_pause_duration"`
+	SpO2Average       *float64 `json:"spo2_average"`
+	Extras            map[string]float64
+}
+
+// fields returns pointers to every modeled field keyed by the API name.
+func (wd *WorkoutData) fields() map[string]**float64 {
+	return map[string]**float64{
+		"calories":            &wd.Calories,
+		"effduration":         &wd.EffectiveDuration,
+		"intensity":           &wd.Intensity,
+		"manual_distance":     &wd.ManualDistance,
+		"manual_calories":     &wd.ManualCalories,
+		"distance":            &wd.Distance,
+		"steps":               &wd.Steps,
+		"elevation":           &wd.Elevation,
+		"metcumul":            &wd.MetCumul,
+		"pool_laps":           &wd.PoolLaps,
+		"pool_length":         &wd.PoolLength,
+		"strokes":             &wd.Strokes,
+		"hr_average":          &wd.HRAverage,
+		"hr_min":              &wd.HRMin,
+		"hr_max":              &wd.HRMax,
+		"hr_zone_0":           &wd.HRZone0,
+		"hr_zone_1":           &wd.HRZone1,
+		"hr_zone_2":           &wd.HRZone2,
+		"hr_zone_3":           &wd.HRZone3,
+		"pause_duration":      &wd.PauseDuration,
+		"algo_pause_duration": &wd.AlgoPauseDuration,
+		"spo2_average":        &wd.SpO2Average,
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler placing unknown fields in Extras.
+func (wd *WorkoutData) UnmarshalJSON(data []byte) error {
+	raw := map[string]*float64{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*wd = WorkoutData{}
+	fields := wd.fields()
+	for k, v := range raw {
+		if f, ok := fields[k]; ok {
+			*f = v
+			continue
+		}
+		if v == nil {
+			continue
+		}
+		if wd.Extras == nil {
+			wd.Extras = map[string]float64{}
+		}
+		wd.Extras[k] = *v
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler writing the data in the same form the
+// API returns it.
+func (wd WorkoutData) MarshalJSON() ([]byte, error) {
+	raw := map[string]float64{}
+	for k, v := range wd.Extras {
+		raw[k] = v
+	}
+	for k, f := range wd.fields() {
+		if *f != nil {
+			raw[k] = **f
+		}
+	}
+	return json.Marshal(raw)
+}
+
 // ActivityMeasuresQueryParam acts as the config parameter for activity measurement queries.
 // All options feilds can be set to null but at least one of the date fields need to be
 // specified or the API will fail. Additionally there is no ParseResponse option as
@@ -275,11 +608,56 @@ type Workout struct {
 type ActivityMeasuresQueryParam struct {
 	UserID int `json:"userid"`
 	// Date             *time.Time `json:"date"`
-	StartDateYMD     *time.Time `json:"startdateymd"`
-	EndDateYMD       *time.Time `json:"enddateymd"`
-	LasteUpdate      *time.Time `json:"lastupdate"`
-	Offset           *int       `json:"offset"`
-	DisableDateParse bool       `json:"diabledateparse"`
+	StartDateYMD     *time.Time      `json:"startdateymd"`
+	EndDateYMD       *time.Time      `json:"enddateymd"`
+	LasteUpdate      *time.Time      `json:"lastupdate"`
+	Offset           *int            `json:"offset"`
+	DataFields       []ActivityField `json:"data_fields"`
+	DisableDateParse bool            `json:"diabledateparse"`
+}
+
+// ActivityField is a data field that can be requested with the activities via
+// ActivityMeasuresQueryParam.DataFields.
+type ActivityField string
+
+// ActivityField constants for the nokia health api.
+const (
+	ActivityFieldSteps         ActivityField = "steps"
+	ActivityFieldDistance      ActivityField = "distance"
+	ActivityFieldElevation     ActivityField = "elevation"
+	ActivityFieldSoft          ActivityField = "soft"
+	ActivityFieldModerate      ActivityField = "moderate"
+	ActivityFieldIntense       ActivityField = "intense"
+	ActivityFieldActive        ActivityField = "active"
+	ActivityFieldCalories      ActivityField = "calories"
+	ActivityFieldTotalCalories ActivityField = "totalcalories"
+	ActivityFieldHRAverage     ActivityField = "hr_average"
+	ActivityFieldHRMin         ActivityField = "hr_min"
+	ActivityFieldHRMax         ActivityField = "hr_max"
+	ActivityFieldHRZone0       ActivityField = "hr_zone_0"
+	ActivityFieldHRZone1       ActivityField = "hr_zone_1"
+	ActivityFieldHRZone2       ActivityField = "hr_zone_2"
+	ActivityFieldHRZone3       ActivityField = "hr_zone_3"
+)
+
+// ActivityFields lists every activity data field.
+var ActivityFields = []ActivityField{
+	ActivityFieldSteps,
+	ActivityFieldDistance,
+	ActivityFieldElevation,
+	ActivityFieldSoft,
+	ActivityFieldModerate,
+	ActivityFieldIntense,
+	ActivityFieldActive,
+	ActivityFieldCalories,
+	ActivityFieldTotalCalories,
+	ActivityFieldHRAverage,
+	ActivityFieldHRMin,
+	ActivityFieldHRMax,
+	ActivityFieldHRZone0,
+	ActivityFieldHRZone1,
+	ActivityFieldHRZone2,
+	ActivityFieldHRZone3,
 }
 
 // ActivitiesMeasuresResp contains the unmarshalled response from the api.
@@ -298,34 +676,322 @@ type ActivitiesMeasuresResp struct {
 // body. As such they are all pointers. You may check SingleValue to determine
 // if a single value was provided.
 type ActivitiesMeasuresRespBody struct {
-	ParsedDate  *time.Time `json:"parseddate"`
-	Date        *string    `json:"date"`
-	Steps       *float64   `json:"steps"`
-	Distance    *float64   `json:"distance"`
-	Calories    *float64   `json:"calories"`
-	Elevation   *float64   `json:"elevation"`
-	Soft        *int       `json:"soft"`
-	Moderate    *int       `json:"moderate"`
-	Intense     *int       `json:"intense"`
-	TimeZone    *string    `json:"timezone"`
-	Activities  []Activity `json:"activity"`
-	More        bool       `json:"more"`
-	Offset      int        `json:"offset"`
-	SingleValue bool       `json:"singleValue"`
-}
-
-// Activity represents an activity as recorded by Nokia Health.
+	ParsedDate    *time.Time `json:"parseddate"`
+	Date          *string    `json:"date"`
+	Steps         *float64   `json:"steps"`
+	Distance      *float64   `json:"distance"`
+	Calories      *float64   `json:"calories"`
+	TotalCalories *float64   `json:"totalcalories"`
+	Elevation     *float64   `json:"elevation"`
+	Soft          *int       `json:"soft"`
+	Moderate      *int       `json:"moderate"`
+	Intense       *int       `json:"intense"`
+	Active        *int       `json:"active"`
+	HRAverage     *int       `json:"hr_average"`
+	HRMin         *int       `json:"hr_min"`
+	HRMax         *int       `json:"hr_max"`
+	HRZone0       *int       `json:"hr_zone_0"`
+	HRZone1       *int       `json:"hr_zone_1"`
+	HRZone2       *int       `json:"hr_zone_2"`
+	HRZone3       *int       `json:"hr_zone_3"`
+	DeviceID      *string    `json:"deviceid"`
+	Brand         *int       `json:"brand"`
+	IsTracker     *bool      `json:"is_tracker"`
+	TimeZone      *string    `json:"timezone"`
+	Activities    []Activity `json:"activity"`
+	More          bool       `json:"more"`
+	Offset        int        `json:"offset"`
+	SingleValue   bool       `json:"singleValue"`
+}
+
+// Activity represents an activity as recorded by Nokia Health. Calories are
+// the active calories while TotalCalories also include the calories burned at
+// rest. Soft, Moderate, Intense and Active are durations in seconds as are the
+// time spent in each of the heart rate zones. The heart rate fields are only
+// set when requested via the data fields and recorded by the device.
 type Activity struct {
-	ParsedDate *time.Time `json:"parseddate"`
-	Date       string     `json:"date"`
-	Steps      float64    `json:"steps"`
-	Distance   float64    `json:"distance"`
-	Calories   float64    `json:"calories"`
-	Elevation  float64    `json:"elevation"`
-	Soft       int        `json:"soft"`
-	Moderate   int        `json:"moderate"`
-	Intense    int        `json:"intense"`
-	TimeZone   string     `json:"timezone"`
+	ParsedDate    *time.Time `json:"parseddate"`
+	Date          string     `json:"date"`
+	Steps         float64    `json:"steps"`
+	Distance      float64    `json:"distance"`
+	Calories      float64    `json:"calories"`
+	TotalCalories float64    `json:"totalcalories"`
+	Elevation     float64    `json:"elevation"`
+	Soft          int        `json:"soft"`
+	Moderate      int        `json:"moderate"`
+	Intense       int        `json:"intense"`
+	Active        int        `json:"active"`
+	HRAverage     int        `json:"hr_average"`
+	HRMin         int        `json:"hr_min"`
+	HRMax         int        `json:"hr_max"`
+	HRZone0       int        `json:"hr_zone_0"`
+	HRZone1       int        `json:"hr_zone_1"`
+	HRZone2       int        `json:"hr_zone_2"`
+	HRZone3       int        `json:"hr_zone_3"`
+	DeviceID      string     `json:"deviceid"`
+	Brand         int        `json:"brand"`
+	IsTracker     bool       `json:"is_tracker"`
+	TimeZone      string     `json:"timezone"`
+}
+
+// Activities returns the activities of the response. When the API returned a
+// single value it is converted to an Activity so both forms can be handled
+// the same way.
+func (rm ActivitiesMeasuresResp) Activities() []Activity {
+	if rm.Body == nil {
+		return nil
+	}
+	if !rm.Body.SingleValue {
+		return rm.Body.Activities
+	}
+
+	b := rm.Body
+	a := Activity{ParsedDate: b.ParsedDate}
+	if b.Date != nil {
+		a.Date = *b.Date
+	}
+	if b.Steps != nil {
+		a.Steps = *b.Steps
+	}
+	if b.Distance != nil {
+		a.Distance = *b.Distance
+	}
+	if b.Calories != nil {
+		a.Calories = *b.Calories
+	}
+	if b.TotalCalories != nil {
+		a.TotalCalories = *b.TotalCalories
+	}
+	if b.Elevation != nil {
+		a.Elevation = *b.Elevation
+	}
+	if b.Soft != nil {
+		a.Soft = *b.Soft
+	}
+	if b.Moderate != nil {
+		a.Moderate = *b.Moderate
+	}
+	if b.Intense != nil {
+		a.Intense = *b.Intense
+	}
+	if b.Active != nil {
+		a.Active = *b.Active
+	}
+	if b.HRAverage != nil {
+		a.HRAverage = *b.HRAverage
+	}
+	if b.HRMin != nil {
+		a.HRMin = *b.HRMin
+	}
+	if b.HRMax != nil {
+		a.HRMax = *b.HRMax
+	}
+	if b.HRZone0 != nil {
+		a.HRZone0 = *b.HRZone0
+	}
+	if b.HRZone1 != nil {
+		a.HRZone1 = *b.HRZone1
+	}
+	if b.HRZone2 != nil {
+		a.HRZone2 = *b.HRZone2
+	}
+	if b.HRZone3 != nil {
+		a.HRZone3 = *b.HRZone3
+	}
+	if b.DeviceID != nil {
+		a.DeviceID = *b.DeviceID
+	}
+	if b.Brand != nil {
+		a.Brand = *b.Brand
+	}
+	if b.IsTracker != nil {
+		a.IsTracker = *b.IsTracker
+	}
+	if b.TimeZone != nil {
+		a.TimeZone = *b.TimeZone
+	}
+	return []Activity{a}
+}
+
+// DevicesResp represents the unmarshelled api response for the user devices.
+type DevicesResp struct {
+	Status      status.Status    `json:"status"`
+	Error       string           `json:"error"`
+	Body        *DevicesRespBody `json:"body"`
+	RawResponse []byte
+	Path        string
+}
+
+// DevicesRespBody represents the unmarshelled api response body for the user devices.
+type DevicesRespBody struct {
+	Devices []Device `json:"devices"`
+}
+
+// Device is a device owned by the user as returned by the API. Type is the
+// name of the device type as returned by the API and TypeParsed the matching
+// DevType, nil if it is unknown. The raw session dates are provided but fully
+// parsed time.Time structs can be accessed via the same name as the field but
+// with Parsed added. Battery is one of low, medium or high.
+type Device struct {
+	Type                   string           `json:"type"`
+	TypeParsed             *devtype.DevType `json:"typeparsed"`
+	Model                  string           `json:"model"`
+	ModelID                int              `json:"model_id"`
+	Battery                string           `json:"battery"`
+	DeviceID               string           `json:"deviceid"`
+	HashDeviceID           string           `json:"hash_deviceid"`
+	Firmware               string           `json:"fw"`
+	MacAddress             string           `json:"mac_address"`
+	TimeZone               string           `json:"timezone"`
+	FirstSessionDate       int64            `json:"first_session_date"`
+	FirstSessionDateParsed *time.Time       `json:"firstsessiondateparsed"`
+	LastSessionDate        int64            `json:"last_session_date"`
+	LastSessionDateParsed  *time.Time       `json:"lastsessiondateparsed"`
+}
+
+// LowBattery returns true if the device reported a low battery level.
+func (d Device) LowBattery() bool {
+	return d.Battery == "low"
+}
+
+// GoalsResp represents the unmarshelled api response for the user goals.
+type GoalsResp struct {
+	Status      status.Status  `json:"status"`
+	Error       string         `json:"error"`
+	Body        *GoalsRespBody `json:"body"`
+	RawResponse []byte
+	Path        string
+}
+
+// GoalsRespBody represents the unmarshelled api response body for the user goals.
+type GoalsRespBody struct {
+	Goals UserGoals `json:"goals"`
+}
+
+// UserGoals are the goals set by the user. Goals the user has not set are nil.
+// Steps is the daily step goal and Sleep the nightly sleep goal in seconds.
+type UserGoals struct {
+	Steps  *int        `json:"steps"`
+	Sleep  *int        `json:"sleep"`
+	Weight *GoalWeight `json:"weight"`
+}
+
+// SleepDuration returns the sleep goal. The second return value is false if
+// the user has not set one.
+func (g UserGoals) SleepDuration() (time.Duration, bool) {
+	if g.Sleep == nil {
+		return 0, false
+	}
+	return time.Duration(*g.Sleep) * time.Second, true
+}
+
+// GoalWeight is the weight goal of the user. As with body measures the value
+// must be multiplied by 10^Unit, which Kgs does.
+type GoalWeight struct {
+	Value int `json:"value"`
+	Unit  int `json:"unit"`
+}
+
+// Kgs returns the weight goal in kilograms.
+func (w GoalWeight) Kgs() float64 {
+	return convertUnits(w.Value, w.Unit)
+}
+
+// HeartListQueryParam acts as the config parameter for heart list retrieval requests.
+// Offset is used to request the next page when the previous response had More set.
+type HeartListQueryParam struct {
+	UserID    int        `json:"userid"`
+	StartDate *time.Time `json:"startdate"`
+	EndDate   *time.Time `json:"enddate"`
+	Offset    *int       `json:"offset"`
+}
+
+// HeartListResp represents the unmarshelled api response for the heart list.
+type HeartListResp struct {
+	Status      status.Status      `json:"status"`
+	Error       string             `json:"error"`
+	Body        *HeartListRespBody `json:"body"`
+	RawResponse []byte
+	Path        string
+}
+
+// HeartListRespBody represents the unmarshelled api response body for the heart list.
+// If More is true there are more recordings to retrieve starting at Offset.
+type HeartListRespBody struct {
+	Series []HeartRecording `json:"series"`
+	More   bool             `json:"more"`
+	Offset int              `json:"offset"`
+}
+
+// HeartRecording is a heart recording as returned by the API. The raw timestamp
+// is provided but the parsed time can be accessed via TimestampParsed.
+// BloodPressure is only set if the device measured it with the recording.
+type HeartRecording struct {
+	DeviceID        string              `json:"deviceid"`
+	Model           int                 `json:"model"`
+	ECG             HeartECG            `json:"ecg"`
+	BloodPressure   *HeartBloodPressure `json:"bloodpressure"`
+	HeartRate       int                 `json:"heart_rate"`
+	Timestamp       int64               `json:"timestamp"`
+	TimestampParsed *time.Time          `json:"timestampparsed"`
+	TimeZone        string              `json:"timezone"`
+}
+
+// HeartECG identifies the ECG signal of a recording and its atrial
+// fibrillation classification. The signal can be retrieved with GetHeartSignal.
+type HeartECG struct {
+	SignalID int       `json:"signalid"`
+	Afib     afib.Afib `json:"afib"`
+}
+
+// HeartBloodPressure is the blood pressure measured with a heart recording in mmHg.
+type HeartBloodPressure struct {
+	Diastole int `json:"diastole"`
+	Systole  int `json:"systole"`
+}
+
+// HeartSignalQueryParam acts as the config parameter for ECG signal retrieval
+// requests. SignalID is required and is found in HeartRecording.ECG.
+type HeartSignalQueryParam struct {
+	UserID        int   `json:"userid"`
+	SignalID      int   `json:"signalid"`
+	WithFiltered  *bool `json:"with_filtered"`
+	WithIntervals *bool `json:"with_intervals"`
+}
+
+// HeartSignalResp represents the unmarshelled api response for an ECG signal.
+type HeartSignalResp struct {
+	Status      status.Status        `json:"status"`
+	Error       string               `json:"error"`
+	Body        *HeartSignalRespBody `json:"body"`
+	RawResponse []byte
+	Path        string
+}
+
+// HeartSignalRespBody represents the unmarshelled api response body for an ECG
+// signal. Signal holds the samples in micro volts recorded at
+// SamplingFrequency hertz.
+type HeartSignalRespBody struct {
+	Signal            []int                     `json:"signal"`
+	SamplingFrequency int                       `json:"sampling_frequency"`
+	WearPosition      wearposition.WearPosition `json:"wearposition"`
+}
+
+// Duration returns the length of the signal.
+func (b HeartSignalRespBody) Duration() time.Duration {
+	if b.SamplingFrequency <= 0 {
+		return 0
+	}
+	return time.Duration(len(b.Signal)) * time.Second / time.Duration(b.SamplingFrequency)
+}
+
+// SampleOffset returns the time of the sample at index i from the start of
+// the signal.
+func (b HeartSignalRespBody) SampleOffset(i int) time.Duration {
+	if b.SamplingFrequency <= 0 {
+		return 0
+	}
+	return time.Duration(i) * time.Second / time.Duration(b.SamplingFrequency)
 }
 
 // BodyMeasuresQueryParams acts as the config parameter for body measurement queries.
@@ -333,6 +999,9 @@ type Activity struct {
 // The ParsedResponse can be set to true and the request will automatically parse
 // the response into easy to use structs. Otherwise this can be done manually when
 // needed via the Parse method.
+// The Category is sent to the API to select real measures or objectives. The
+// Filters are applied client side when the response is parsed, as the API has
+// no way to filter on the attrib of a measure group.
 type BodyMeasuresQueryParams struct {
 	UserID        int                `json:"userid"`
 	StartDate     *time.Time         `json:"startdate"`
@@ -340,10 +1009,55 @@ type BodyMeasuresQueryParams struct {
 	LastUpdate    *time.Time         `json:"lastupdate"`
 	DevType       *devtype.DevType   `json:"devtype"`
 	MeasType      *meastype.MeasType `json:"meastype"`
-	Category      *int               `json:"category"`
+	Category      *category.Category `json:"category"`
 	Limit         *int               `json:"limit"`
 	Offset        *int               `json:"offset"`
 	ParseResponse bool
+	Filters       []MeasureGroupFilter
+}
+
+// MeasureGroupFilter decides if a measure group should be included when
+// parsing body measures. It returns true to keep the group.
+type MeasureGroupFilter func(g BodyMeasureGroupResp) bool
+
+// DeviceMeasuredOnly keeps only the measure groups captured by a device,
+// excluding manual entries.
+func DeviceMeasuredOnly() MeasureGroupFilter {
+	return func(g BodyMeasureGroupResp) bool {
+		return g.Attrib.IsDevice()
+	}
+}
+
+// ExcludeManual excludes measure groups entered manually by the user.
+func ExcludeManual() MeasureGroupFilter {
+	return func(g BodyMeasureGroupResp) bool {
+		return !g.Attrib.IsManual()
+	}
+}
+
+// ExcludeAmbiguous excludes measure groups that may belong to another user.
+func ExcludeAmbiguous() MeasureGroupFilter {
+	return func(g BodyMeasureGroupResp) bool {
+		return !g.Attrib.IsAmbiguous()
+	}
+}
+
+// OnlyCategory keeps only the measure groups of the category provided. Use
+// category.RealMeasurement to exclude the user objectives.
+func OnlyCategory(c category.Category) MeasureGroupFilter {
+	return func(g BodyMeasureGroupResp) bool {
+		return g.Category == c
+	}
+}
+
+// include returns true if the group passes all the filters.
+func include(g BodyMeasureGroupResp, filters []MeasureGroupFilter) bool {
+	for _, f := range filters {
+		if !f(g) {
+			return false
+		}
+	}
+	return true
 }
 
 // BodyMeasuresResp contains the unmarshalled response from the api.
@@ -360,304 +1074,425 @@ type BodyMeasuresResp struct {
 
 // BodyMeasureRespBody represents the body portion of the body measure response.
 // The body portion is not required and thus this may not be found in the response
-// object.
+// object. If More is not 0 there are more groups to retrieve starting at Offset.
 type BodyMeasureRespBody struct {
 	Updatetime  int64                  `json:"updatetime"`
 	More        int                    `json:"more"`
+	Offset      int                    `json:"offset"`
 	Timezone    string                 `json:"timezone"`
 	MeasureGrps []BodyMeasureGroupResp `json:"measuregrps"`
 }
 
 // BodyMeasureGroupResp is a single body measurment group as found in the resposne.
 // Each group has a set of measures that can then be parsed manually or via the
-// Parse method on BodyMeasuresQueryParams.
+// Parse method on BodyMeasuresQueryParams. Created and Modified are the unix
+// timestamps at which the group was created and last modified.
 type BodyMeasureGroupResp struct {
 	GrpID    int                   `json:"grpid"`
-	Attrib   int                   `json:"attrib"`
+	Attrib   attrib.Attrib         `json:"attrib"`
 	Date     int64                 `json:"date"`
-	Category int                   `json:"category"`
+	Created  int64                 `json:"created"`
+	Modified int64                 `json:"modified"`
+	Category category.Category     `json:"category"`
 	Measures []BodyMeasuresMeasure `json:"measures"`
 }
 
-// BodyMeasuresMeasure is a single body measure found in the response.
+// BodyMeasuresMeasure is a single body measure found in the response. Position
+// is only provided for segmental measures and identifies the body segment.
 type BodyMeasuresMeasure struct {
-	Value int               `json:"value"`
-	Type  meastype.MeasType `json:"type"`
-	Unit  int               `json:"unit"`
+	Value    int               `json:"value"`
+	Type     meastype.MeasType `json:"type"`
+	Unit     int               `json:"unit"`
+	Position int               `json:"position"`
 }
 
 type Weight struct {
 	Date     time.Time
+	GrpID    int
 	Kgs      float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type Height struct {
 	Date     time.Time
+	GrpID    int
 	Meters   float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type FatFreeMass struct {
 	Date     time.Time
+	GrpID    int
 	Kgs      float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type FatMassWeight struct {
 	Date     time.Time
+	GrpID    int
 	Kgs      float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type FatRatio struct {
 	Date     time.Time
+	GrpID    int
 	Ratio    float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type DiastolicBloodPressure struct {
 	Date     time.Time
+	GrpID    int
 	MmHg     float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type SystolicBloodPressure struct {
 	Date     time.Time
+	GrpID    int
 	MmHg     float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type HeartPulse struct {
 	Date     time.Time
+	GrpID    int
 	BPM      float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type Temperature struct {
 	Date     time.Time
+	GrpID    int
 	Celcius  float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type SP02Percent struct {
 	Date       time.Time
+	GrpID      int
 	Percentage float64
-	Attrib     int
-	Category   int
+	Attrib     attrib.Attrib
+	Category   category.Category
 }
 
 type BodyTemperature struct {
 	Date     time.Time
+	GrpID    int
 	Celcius  float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type SkinTemperature struct {
 	Date     time.Time
+	GrpID    int
 	Celcius  float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type MuscleMass struct {
 	Date     time.Time
+	GrpID    int
 	Mass     float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type Hydration struct {
 	Date      time.Time
+	GrpID     int
 	Hydration float64
-	Attrib    int
-	Category  int
+	Attrib    attrib.Attrib
+	Category  category.Category
 }
 
 type BoneMass struct {
 	Date     time.Time
+	GrpID    int
 	Mass     float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type PulseWaveVelocity struct {
 	Date     time.Time
+	GrpID    int
 	Velocity float64
-	Attrib   int
-	Category int
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+// VO2Max is the maximal oxygen consumption in ml/min/kg.
+type VO2Max struct {
+	Date        time.Time
+	GrpID       int
+	MlPerMinKgs float64
+	Attrib      attrib.Attrib
+	Category    category.Category
+}
+
+// AtrialFibrillation is the result of an atrial fibrillation check classified
+// like the ECG of the heart recordings.
+type AtrialFibrillation struct {
+	Date     time.Time
+	GrpID    int
+	Result   afib.Afib
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+// ECGInterval is an interval duration calculated from an ECG signal.
+type ECGInterval struct {
+	Date         time.Time
+	GrpID        int
+	Milliseconds float64
+	Attrib       attrib.Attrib
+	Category     category.Category
+}
+
+type VascularAge struct {
+	Date     time.Time
+	GrpID    int
+	Years    float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+// NerveHealthScore is the nerve health score conductance measured through
+// the feet electrodes.
+type NerveHealthScore struct {
+	Date     time.Time
+	GrpID    int
+	Score    float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type ExtracellularWater struct {
+	Date     time.Time
+	GrpID    int
+	Kgs      float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type IntracellularWater struct {
+	Date     time.Time
+	GrpID    int
+	Kgs      float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+// VisceralFat is the visceral fat index. The API does not provide a unit.
+type VisceralFat struct {
+	Date     time.Time
+	GrpID    int
+	Index    float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+// SegmentMass is a mass measured for a single body segment. Position is the
+// segment as reported by the API.
+type SegmentMass struct {
+	Date     time.Time
+	GrpID    int
+	Kgs      float64
+	Position int
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type ElectrodermalActivity struct {
+	Date     time.Time
+	GrpID    int
+	Activity float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type BasalMetabolicRate struct {
+	Date     time.Time
+	GrpID    int
+	Kcal     float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type MetabolicAge struct {
+	Date     time.Time
+	GrpID    int
+	Years    float64
+	Attrib   attrib.Attrib
+	Category category.Category
+}
+
+type ElectrochemicalSkinConductance struct {
+	Date         time.Time
+	GrpID        int
+	MicroSiemens float64
+	Attrib       attrib.Attrib
+	Category     category.Category
+}
+
+// OtherMeasure holds any measure whose type is not modeled by BodyMeasures so
+// that new measure types returned by the API are not lost during parsing.
+type OtherMeasure struct {
+	Date     time.Time
+	GrpID    int
+	Type     meastype.MeasType
+	Value    float64
+	Attrib   attrib.Attrib
+	Category category.Category
 }
 
 type BodyMeasures struct {
-	Weights                 []Weight
-	Heights                 []Height
-	FatFreeMass             []FatFreeMass
-	FatRatios               []FatRatio
-	FatMassWeights          []FatMassWeight
-	DiastolicBloodPressures []DiastolicBloodPressure
-	SystolicBloodPressures  []SystolicBloodPressure
-	HeartPulses             []HeartPulse
-	Temperatures            []Temperature
-	SP02Percents            []SP02Percent
-	BodyTemperatures        []BodyTemperature
-	SkinTemperatures        []SkinTemperature
-	MuscleMasses            []MuscleMass
-	Hydration               []Hydration
-	BoneMasses              []BoneMass
-	PulseWaveVelocity       []PulseWaveVelocity
+	Weights                         []Weight
+	Heights                         []Height
+	FatFreeMass                     []FatFreeMass
+	FatRatios                       []FatRatio
+	FatMassWeights                  []FatMassWeight
+	DiastolicBloodPressures         []DiastolicBloodPressure
+	SystolicBloodPressures          []SystolicBloodPressure
+	HeartPulses                     []HeartPulse
+	Temperatures                    []Temperature
+	SP02Percents                    []SP02Percent
+	BodyTemperatures                []BodyTemperature
+	SkinTemperatures                []SkinTemperature
+	MuscleMasses                    []MuscleMass
+	Hydration                       []Hydration
+	BoneMasses                      []BoneMass
+	PulseWaveVelocity               []PulseWaveVelocity
+	VO2Max                          []VO2Max
+	AtrialFibrillationECG           []AtrialFibrillation
+	AtrialFibrillationPPG           []AtrialFibrillation
+	QRSIntervals                    []ECGInterval
+	PRIntervals                     []ECGInterval
+	QTIntervals                     []ECGInterval
+	CorrectedQTIntervals            []ECGInterval
+	VascularAges                    []VascularAge
+	NerveHealthScores               []NerveHealthScore
+	ExtracellularWater              []ExtracellularWater
+	IntracellularWater              []IntracellularWater
+	VisceralFat                     []VisceralFat
+	FatFreeMassSegments             []SegmentMass
+	FatMassSegments                 []SegmentMass
+	MuscleMassSegments              []SegmentMass
+	ElectrodermalActivityFeet       []ElectrodermalActivity
+	BasalMetabolicRates             []BasalMetabolicRate
+	MetabolicAges                   []MetabolicAge
+	ElectrochemicalSkinConductances []ElectrochemicalSkinConductance
+	Other                           []OtherMeasure
 }
 
 // ParseData parses all the data provided into buckets of each type of
 // measurement. It also performs the nessasary date and unit conversion.
-func (rm BodyMeasuresResp) ParseData() *BodyMeasures {
+// Measures of a type that is not modeled are placed in Other. Only the
+// measure groups passing all the filters provided are parsed.
+func (rm BodyMeasuresResp) ParseData(filters ...MeasureGroupFilter) *BodyMeasures {
 	bm := BodyMeasures{}
 
 	if rm.Body != nil {
 		// process all measurements
-		for mgID, _ := range rm.Body.MeasureGrps {
-			// build the time
-			d := time.Unix(int64(rm.Body.MeasureGrps[mgID].Date), 0)
-
-			for mID, _ := range rm.Body.MeasureGrps[mgID].Measures {
-				switch {
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.Weight:
-					w := Weight{
-						Date:     d,
-						Kgs:      convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.Weights = append(bm.Weights, w)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.Height:
-					h := Height{
-						Date:     d,
-						Meters:   convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.Heights = append(bm.Heights, h)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.FatFreeMassKg:
-					ffm := FatFreeMass{
-						Date:     d,
-						Kgs:      convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.FatFreeMass = append(bm.FatFreeMass, ffm)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.FatRatio:
-					fr := FatRatio{
-						Date:     d,
-						Ratio:    convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.FatRatios = append(bm.FatRatios, fr)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.FatMassWeightKg:
-					fmw := FatMassWeight{
-						Date:     d,
-						Kgs:      convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.FatMassWeights = append(bm.FatMassWeights, fmw)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.DiastolicBloodPressureMMHG:
-					dbp := DiastolicBloodPressure{
-						Date:     d,
-						MmHg:     convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.DiastolicBloodPressures = append(bm.DiastolicBloodPressures, dbp)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.SystolicBloodPressureMMHG:
-					sbp := SystolicBloodPressure{
-						Date:     d,
-						MmHg:     convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.SystolicBloodPressures = append(bm.SystolicBloodPressures, sbp)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.HeartPulseBPM:
-					hp := HeartPulse{
-						Date:     d,
-						BPM:      convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.HeartPulses = append(bm.HeartPulses, hp)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.Temperature:
-					t := Temperature{
-						Date:     d,
-						Celcius:  convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.Temperatures = append(bm.Temperatures, t)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.SP02Percent:
-					p := SP02Percent{
-						Date:       d,
-						Percentage: convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:     rm.Body.MeasureGrps[mgID].Attrib,
-						Category:   rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.SP02Percents = append(bm.SP02Percents, p)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.BodyTemperature:
-					t := BodyTemperature{
-						Date:     d,
-						Celcius:  convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.BodyTemperatures = append(bm.BodyTemperatures, t)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.SkinTemperature:
-					t := SkinTemperature{
-						Date:     d,
-						Celcius:  convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.SkinTemperatures = append(bm.SkinTemperatures, t)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.MuscleMass:
-					m := MuscleMass{
-						Date:     d,
-						Mass:     convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.MuscleMasses = append(bm.MuscleMasses, m)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.Hydration:
-					h := Hydration{
-						Date:      d,
-						Hydration: convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:    rm.Body.MeasureGrps[mgID].Attrib,
-						Category:  rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.Hydration = append(bm.Hydration, h)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.BoneMass:
-					m := BoneMass{
-						Date:     d,
-						Mass:     convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.BoneMasses = append(bm.BoneMasses, m)
-				case rm.Body.MeasureGrps[mgID].Measures[mID].Type == meastype.PulseWaveVelocity:
-					v := PulseWaveVelocity{
-						Date:     d,
-						Velocity: convertUnits(rm.Body.MeasureGrps[mgID].Measures[mID].Value, rm.Body.MeasureGrps[mgID].Measures[mID].Unit),
-						Attrib:   rm.Body.MeasureGrps[mgID].Attrib,
-						Category: rm.Body.MeasureGrps[mgID].Category,
-					}
-					bm.PulseWaveVelocity = append(bm.PulseWaveVelocity, v)
+		for mgID := range rm.Body.MeasureGrps {
+			g := rm.Body.MeasureGrps[mgID]
+			if !include(g, filters) {
+				continue
+			}
+
+			// build the time in the time zone of the user
+			d := ParseUnix(int64(g.Date), rm.Body.Timezone)
+
+			for mID := range g.Measures {
+				m := g.Measures[mID]
+				v := convertUnits(m.Value, m.Unit)
+
+				switch m.Type {
+				case meastype.Weight:
+					bm.Weights = append(bm.Weights, Weight{Date: d, GrpID: g.GrpID, Kgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.Height:
+					bm.Heights = append(bm.Heights, Height{Date: d, GrpID: g.GrpID, Meters: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.FatFreeMassKg:
+					bm.FatFreeMass = append(bm.FatFreeMass, FatFreeMass{Date: d, GrpID: g.GrpID, Kgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.FatRatio:
+					bm.FatRatios = append(bm.FatRatios, FatRatio{Date: d, GrpID: g.GrpID, Ratio: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.FatMassWeightKg:
+					bm.FatMassWeights = append(bm.FatMassWeights, FatMassWeight{Date: d, GrpID: g.GrpID, Kgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.DiastolicBloodPressureMMHG:
+					bm.DiastolicBloodPressures = append(bm.DiastolicBloodPressures, DiastolicBloodPressure{Date: d, GrpID: g.GrpID, MmHg: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.SystolicBloodPressureMMHG:
+					bm.SystolicBloodPressures = append(bm.SystolicBloodPressures, SystolicBloodPressure{Date: d, GrpID: g.GrpID, MmHg: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.HeartPulseBPM:
+					bm.HeartPulses = append(bm.HeartPulses, HeartPulse{Date: d, GrpID: g.GrpID, BPM: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.Temperature:
+					bm.Temperatures = append(bm.Temperatures, Temperature{Date: d, GrpID: g.GrpID, Celcius: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.SP02Percent:
+					bm.SP02Percents = append(bm.SP02Percents, SP02Percent{Date: d, GrpID: g.GrpID, Percentage: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.BodyTemperature:
+					bm.BodyTemperatures = append(bm.BodyTemperatures, BodyTemperature{Date: d, GrpID: g.GrpID, Celcius: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.SkinTemperature:
+					bm.SkinTemperatures = append(bm.SkinTemperatures, SkinTemperature{Date: d, GrpID: g.GrpID, Celcius: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.MuscleMass:
+					bm.MuscleMasses = append(bm.MuscleMasses, MuscleMass{Date: d, GrpID: g.GrpID, Mass: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.Hydration:
+					bm.Hydration = append(bm.Hydration, Hydration{Date: d, GrpID: g.GrpID, Hydration: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.BoneMass:
+					bm.BoneMasses = append(bm.BoneMasses, BoneMass{Date: d, GrpID: g.GrpID, Mass: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.PulseWaveVelocity:
+					bm.PulseWaveVelocity = append(bm.PulseWaveVelocity, PulseWaveVelocity{Date: d, GrpID: g.GrpID, Velocity: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.VO2Max:
+					bm.VO2Max = append(bm.VO2Max, VO2Max{Date: d, GrpID: g.GrpID, MlPerMinKgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.AtrialFibrillationECG:
+					bm.AtrialFibrillationECG = append(bm.AtrialFibrillationECG, AtrialFibrillation{Date: d, GrpID: g.GrpID, Result: afib.Afib(math.Round(v)), Attrib: g.Attrib, Category: g.Category})
+				case meastype.AtrialFibrillationPPG:
+					bm.AtrialFibrillationPPG = append(bm.AtrialFibrillationPPG, AtrialFibrillation{Date: d, GrpID: g.GrpID, Result: afib.Afib(math.Round(v)), Attrib: g.Attrib, Category: g.Category})
+				case meastype.QRSIntervalDuration:
+					bm.QRSIntervals = append(bm.QRSIntervals, ECGInterval{Date: d, GrpID: g.GrpID, Milliseconds: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.PRIntervalDuration:
+					bm.PRIntervals = append(bm.PRIntervals, ECGInterval{Date: d, GrpID: g.GrpID, Milliseconds: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.QTIntervalDuration:
+					bm.QTIntervals = append(bm.QTIntervals, ECGInterval{Date: d, GrpID: g.GrpID, Milliseconds: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.CorrectedQTIntervalDuration:
+					bm.CorrectedQTIntervals = append(bm.CorrectedQTIntervals, ECGInterval{Date: d, GrpID: g.GrpID, Milliseconds: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.VascularAge:
+					bm.VascularAges = append(bm.VascularAges, VascularAge{Date: d, GrpID: g.GrpID, Years: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.NerveHealthScore:
+					bm.NerveHealthScores = append(bm.NerveHealthScores, NerveHealthScore{Date: d, GrpID: g.GrpID, Score: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.ExtracellularWater:
+					bm.ExtracellularWater = append(bm.ExtracellularWater, ExtracellularWater{Date: d, GrpID: g.GrpID, Kgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.IntracellularWater:
+					bm.IntracellularWater = append(bm.IntracellularWater, IntracellularWater{Date: d, GrpID: g.GrpID, Kgs: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.VisceralFat:
+					bm.VisceralFat = append(bm.VisceralFat, VisceralFat{Date: d, GrpID: g.GrpID, Index: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.FatFreeMassSegments:
+					bm.FatFreeMassSegments = append(bm.FatFreeMassSegments, SegmentMass{Date: d, GrpID: g.GrpID, Kgs: v, Position: m.Position, Attrib: g.Attrib, Category: g.Category})
+				case meastype.FatMassSegments:
+					bm.FatMassSegments = append(bm.FatMassSegments, SegmentMass{Date: d, GrpID: g.GrpID, Kgs: v, Position: m.Position, Attrib: g.Attrib, Category: g.Category})
+				case meastype.MuscleMassSegments:
+					bm.MuscleMassSegments = append(bm.MuscleMassSegments, SegmentMass{Date: d, GrpID: g.GrpID, Kgs: v, Position: m.Position, Attrib: g.Attrib, Category: g.Category})
+				case meastype.ElectrodermalActivityFeet:
+					bm.ElectrodermalActivityFeet = append(bm.ElectrodermalActivityFeet, ElectrodermalActivity{Date: d, GrpID: g.GrpID, Activity: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.BasalMetabolicRate:
+					bm.BasalMetabolicRates = append(bm.BasalMetabolicRates, BasalMetabolicRate{Date: d, GrpID: g.GrpID, Kcal: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.MetabolicAge:
+					bm.MetabolicAges = append(bm.MetabolicAges, MetabolicAge{Date: d, GrpID: g.GrpID, Years: v, Attrib: g.Attrib, Category: g.Category})
+				case meastype.ElectrochemicalSkinConductance:
+					bm.ElectrochemicalSkinConductances = append(bm.ElectrochemicalSkinConductances, ElectrochemicalSkinConductance{Date: d, GrpID: g.GrpID, MicroSiemens: v, Attrib: g.Attrib, Category: g.Category})
+				default:
+					bm.Other = append(bm.Other, OtherMeasure{Date: d, GrpID: g.GrpID, Type: m.Type, Value: v, Attrib: g.Attrib, Category: g.Category})
 				}
 			}
 		}
@@ -0,0 +1,226 @@
+package nokiahealth
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/jrmycanady/nokiahealth/enum/afib"
+	"github.com/jrmycanady/nokiahealth/enum/attrib"
+	"github.com/jrmycanady/nokiahealth/enum/category"
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+)
+
+const bodyMeasuresJSON = `{
+	"status": 0,
+	"body": {
+		"updatetime": 1540000000,
+		"timezone": "Europe/Paris",
+		"measuregrps": [
+			{
+				"grpid": 1,
+				"attrib": 0,
+				"date": 1539990000,
+				"category": 1,
+				"measures": [
+					{"value": 72500, "type": 1, "unit": -3},
+					{"value": 4800, "type": 123, "unit": -2},
+					{"value": 1, "type": 130, "unit": 0},
+					{"value": 98, "type": 135, "unit": 0},
+					{"value": 412, "type": 138, "unit": 0},
+					{"value": 3210, "type": 168, "unit": -3},
+					{"value": 8, "type": 170, "unit": 0},
+					{"value": 2510, "type": 174, "unit": -3, "position": 12},
+					{"value": 42, "type": 999, "unit": 0}
+				]
+			}
+		]
+	}
+}`
+
+func TestBodyMeasuresParseData(t *testing.T) {
+	var resp BodyMeasuresResp
+	if err := json.Unmarshal([]byte(bodyMeasuresJSON), &resp); err != nil {
+		t.Fatalf("failed to unmarshal body measures: %s", err)
+	}
+
+	bm := resp.ParseData()
+
+	if len(bm.Weights) != 1 || math.Abs(bm.Weights[0].Kgs-72.5) > 1e-9 {
+		t.Fatalf("unexpected weights: %+v", bm.Weights)
+	}
+	if len(bm.VO2Max) != 1 || math.Abs(bm.VO2Max[0].MlPerMinKgs-48) > 1e-9 {
+		t.Fatalf("unexpected vo2 max: %+v", bm.VO2Max)
+	}
+	if len(bm.AtrialFibrillationECG) != 1 || bm.AtrialFibrillationECG[0].Result != afib.Positive {
+		t.Fatalf("unexpected afib: %+v", bm.AtrialFibrillationECG)
+	}
+	if len(bm.QRSIntervals) != 1 || bm.QRSIntervals[0].Milliseconds != 98 {
+		t.Fatalf("unexpected qrs intervals: %+v", bm.QRSIntervals)
+	}
+	if len(bm.CorrectedQTIntervals) != 1 || bm.CorrectedQTIntervals[0].Milliseconds != 412 {
+		t.Fatalf("unexpected qtc intervals: %+v", bm.CorrectedQTIntervals)
+	}
+	if len(bm.ExtracellularWater) != 1 || math.Abs(bm.ExtracellularWater[0].Kgs-3.21) > 1e-9 {
+		t.Fatalf("unexpected extracellular water: %+v", bm.ExtracellularWater)
+	}
+	if len(bm.VisceralFat) != 1 || bm.VisceralFat[0].Index != 8 {
+		t.Fatalf("unexpected visceral fat: %+v", bm.VisceralFat)
+	}
+	if len(bm.FatMassSegments) != 1 || bm.FatMassSegments[0].Position != 12 {
+		t.Fatalf("unexpected fat mass segments: %+v", bm.FatMassSegments)
+	}
+	if len(bm.Other) != 1 || bm.Other[0].Type != meastype.MeasType(999) {
+		t.Fatalf("unknown measure was not kept: %+v", bm.Other)
+	}
+}
+
+func TestBodyMeasuresParseDataFilters(t *testing.T) {
+	resp := BodyMeasuresResp{
+		Body: &BodyMeasureRespBody{
+			MeasureGrps: []BodyMeasureGroupResp{
+				{GrpID: 1, Attrib: attrib.DeviceEntryForUser, Category: category.RealMeasurement, Measures: []BodyMeasuresMeasure{{Value: 70, Type: meastype.Weight}}},
+				{GrpID: 2, Attrib: attrib.DeviceEntryForUserAmbiguous, Category: category.RealMeasurement, Measures: []BodyMeasuresMeasure{{Value: 90, Type: meastype.Weight}}},
+				{GrpID: 3, Attrib: attrib.ManualUserEntry, Category: category.RealMeasurement, Measures: []BodyMeasuresMeasure{{Value: 71, Type: meastype.Weight}}},
+				{GrpID: 4, Attrib: attrib.ManualUserEntry, Category: category.UserObjective, Measures: []BodyMeasuresMeasure{{Value: 65, Type: meastype.Weight}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		filters []MeasureGroupFilter
+		want    []float64
+	}{
+		{"none", nil, []float64{70, 90, 71, 65}},
+		{"device only", []MeasureGroupFilter{DeviceMeasuredOnly()}, []float64{70, 90}},
+		{"exclude ambiguous", []MeasureGroupFilter{ExcludeAmbiguous()}, []float64{70, 71, 65}},
+		{"objectives", []MeasureGroupFilter{OnlyCategory(category.UserObjective)}, []float64{65}},
+		{"real and unambiguous", []MeasureGroupFilter{OnlyCategory(category.RealMeasurement), ExcludeAmbiguous(), ExcludeManual()}, []float64{70}},
+	}
+
+	for _, test := range tests {
+		bm := resp.ParseData(test.filters...)
+		if len(bm.Weights) != len(test.want) {
+			t.Fatalf("%s: expected %d weights got %d", test.name, len(test.want), len(bm.Weights))
+		}
+		for i := range test.want {
+			if bm.Weights[i].Kgs != test.want[i] {
+				t.Errorf("%s: expected weight %f got %f", test.name, test.want[i], bm.Weights[i].Kgs)
+			}
+		}
+	}
+}
+
+func TestSleepSummaryDataFields(t *testing.T) {
+	raw := `{"status":0,"body":{"series":[{"id":1,"timezone":"UTC","data":{
+		"lightsleepduration":3600,"hr_average":55,"hr_min":48,"rr_average":14.5,
+		"breathing_disturbances_intensity":12,"snoring":300,"sleep_score":81,
+		"night_events":{"1":[0]}}}]}}`
+
+	var resp SleepSummaryResp
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to unmarshal sleep summary: %s", err)
+	}
+
+	d := resp.Body.Series[0].Data
+	if d.LightSleepDuration == nil || *d.LightSleepDuration != 3600 {
+		t.Errorf("unexpected light sleep duration %v", d.LightSleepDuration)
+	}
+	if d.DeepSleepDuration != nil {
+		t.Errorf("expected missing deep sleep duration to be nil")
+	}
+	if d.HRAverage == nil || *d.HRAverage != 55 || d.RRAverage == nil || *d.RRAverage != 14.5 {
+		t.Errorf("unexpected heart and respiration rates %v %v", d.HRAverage, d.RRAverage)
+	}
+	if d.SleepScore == nil || *d.SleepScore != 81 || len(d.NightEvents) == 0 {
+		t.Errorf("unexpected sleep score %v or night events %s", d.SleepScore, d.NightEvents)
+	}
+}
+
+func TestSleepMeasuresSeries(t *testing.T) {
+	raw := `{"status":0,"body":{"series":[
+		{"startdate":1000,"enddate":1120,"state":1,"hr":{"1060":58,"1000":60},"rr":{"1000":14}},
+		{"startdate":1120,"enddate":1180,"state":2,"hr":{"1120":55},"snoring":{"1120":1}}
+	]}}`
+
+	var resp SleepMeasuresResp
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to unmarshal sleep measures: %s", err)
+	}
+
+	hr := resp.HeartRate()
+	if len(hr) != 3 || hr[0].Value != 60 || hr[1].Value != 58 || hr[2].Date.Unix() != 1120 {
+		t.Errorf("unexpected heart rate series %+v", hr)
+	}
+	if rr := resp.RespirationRate(); len(rr) != 1 || rr[0].Value != 14 {
+		t.Errorf("unexpected respiration rate series %+v", rr)
+	}
+	if s := resp.Snoring(); len(s) != 1 || s[0].Date.Unix() != 1120 {
+		t.Errorf("unexpected snoring series %+v", s)
+	}
+}
+
+func TestWorkoutData(t *testing.T) {
+	raw := `{"status":0,"body":{"series":[{"id":7,"category":2,"timezone":"UTC","data":{
+		"calories":312.5,"effduration":1800,"distance":5012,"steps":6100,"hr_average":151,
+		"hr_zone_2":900,"pool_laps":null,"new_field":3}}]}}`
+
+	var resp WorkoutResponse
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to unmarshal workouts: %s", err)
+	}
+
+	w := resp.Body.Series[0]
+	if w.Category == nil || w.Category.String() != "Run" {
+		t.Errorf("unexpected category %v", w.Category)
+	}
+	d := w.Data
+	if d.Calories == nil || *d.Calories != 312.5 || d.EffectiveDuration == nil || *d.EffectiveDuration != 1800 {
+		t.Errorf("unexpected calories or duration %v %v", d.Calories, d.EffectiveDuration)
+	}
+	if d.HRZone2 == nil || *d.HRZone2 != 900 || d.PoolLaps != nil {
+		t.Errorf("unexpected heart rate zone or pool laps %v %v", d.HRZone2, d.PoolLaps)
+	}
+	if d.Extras["new_field"] != 3 {
+		t.Errorf("expected unknown field to be kept got %v", d.Extras)
+	}
+
+	out, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("failed to marshal workout data: %s", err)
+	}
+	var back WorkoutData
+	if err := json.Unmarshal(out, &back); err != nil || *back.Distance != 5012 || back.Extras["new_field"] != 3 {
+		t.Errorf("workout data did not round trip: %s %v", out, err)
+	}
+}
+
+func TestActivitiesSingleValue(t *testing.T) {
+	raw := `{"status":0,"body":{"date":"2018-07-01","timezone":"UTC","steps":8000,"totalcalories":2300.5,
+		"active":1800,"hr_average":72,"hr_zone_2":600,"deviceid":"abc","brand":1,"is_tracker":true}}`
+
+	var resp ActivitiesMeasuresResp
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("failed to unmarshal activities: %s", err)
+	}
+	resp.Body.SingleValue = true
+
+	acts := resp.Activities()
+	if len(acts) != 1 {
+		t.Fatalf("expected a single activity got %d", len(acts))
+	}
+	a := acts[0]
+	if a.Date != "2018-07-01" || a.Steps != 8000 || a.TotalCalories != 2300.5 || a.Active != 1800 {
+		t.Errorf("unexpected activity %+v", a)
+	}
+	if a.HRAverage != 72 || a.HRZone2 != 600 || a.DeviceID != "abc" || a.Brand != 1 || !a.IsTracker {
+		t.Errorf("unexpected heart rate or device fields %+v", a)
+	}
+
+	resp.Body.SingleValue = false
+	resp.Body.Activities = []Activity{{Date: "2018-07-02"}, {Date: "2018-07-03"}}
+	if acts := resp.Activities(); len(acts) != 2 {
+		t.Errorf("expected the activities of the body got %+v", acts)
+	}
+}
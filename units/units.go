@@ -0,0 +1,393 @@
+// Package units provides unit systems and conversion for the measurements
+// returned by the Nokia Health API. The API reports everything in metric so
+// a Quantity can be created from any parsed value and then converted or
+// rendered in the unit system the user prefers.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+)
+
+// Dimension is the physical dimension a Unit measures. Only units of the same
+// dimension can be converted between each other.
+type Dimension int
+
+// Dimension constants.
+const (
+	Dimensionless Dimension = iota
+	Mass
+	Length
+	Temperature
+	Pressure
+	Frequency
+	Velocity
+	Duration
+	Energy
+	OxygenUptake
+	Conductance
+	Age
+)
+
+// Unit is a unit of measure.
+type Unit int
+
+// Unit constants.
+const (
+	None Unit = iota
+	Kilogram
+	Gram
+	Pound
+	Stone
+	Meter
+	Centimeter
+	Kilometer
+	Inch
+	Foot
+	Yard
+	Mile
+	Celsius
+	Fahrenheit
+	Percent
+	MillimeterOfMercury
+	BeatsPerMinute
+	MeterPerSecond
+	Millisecond
+	Second
+	Minute
+	Hour
+	Kilocalorie
+	MilliliterPerMinutePerKilogram
+	Microsiemens
+	Year
+)
+
+// unitInfo holds the symbol and dimension of a unit. factor and offset convert
+// a value in the unit to the base unit of its dimension as base = v*factor + offset.
+type unitInfo struct {
+	symbol    string
+	dimension Dimension
+	factor    float64
+	offset    float64
+}
+
+const (
+	kgsPerPound   = 0.45359237
+	metersPerInch = 0.0254
+)
+
+var unitInfos = map[Unit]unitInfo{
+	None:                           {"", Dimensionless, 1, 0},
+	Kilogram:                       {"kg", Mass, 1, 0},
+	Gram:                           {"g", Mass, 0.001, 0},
+	Pound:                          {"lb", Mass, kgsPerPound, 0},
+	Stone:                          {"st", Mass, kgsPerPound * 14, 0},
+	Meter:                          {"m", Length, 1, 0},
+	Centimeter:                     {"cm", Length, 0.01, 0},
+	Kilometer:                      {"km", Length, 1000, 0},
+	Inch:                           {"in", Length, metersPerInch, 0},
+	Foot:                           {"ft", Length, metersPerInch * 12, 0},
+	Yard:                           {"yd", Length, metersPerInch * 36, 0},
+	Mile:                           {"mi", Length, metersPerInch * 63360, 0},
+	Celsius:                        {"°C", Temperature, 1, 0},
+	Fahrenheit:                     {"°F", Temperature, 5.0 / 9.0, -32 * 5.0 / 9.0},
+	Percent:                        {"%", Dimensionless, 1, 0},
+	MillimeterOfMercury:            {"mmHg", Pressure, 1, 0},
+	BeatsPerMinute:                 {"bpm", Frequency, 1, 0},
+	MeterPerSecond:                 {"m/s", Velocity, 1, 0},
+	Millisecond:                    {"ms", Duration, 0.001, 0},
+	Second:                         {"s", Duration, 1, 0},
+	Minute:                         {"min", Duration, 60, 0},
+	Hour:                           {"h", Duration, 3600, 0},
+	Kilocalorie:                    {"kcal", Energy, 1, 0},
+	MilliliterPerMinutePerKilogram: {"ml/min/kg", OxygenUptake, 1, 0},
+	Microsiemens:                   {"µS", Conductance, 1, 0},
+	Year:                           {"years", Age, 1, 0},
+}
+
+// Symbol returns the symbol used when rendering the unit, i.e. kg.
+func (u Unit) Symbol() string {
+	return unitInfos[u].symbol
+}
+
+// Dimension returns the dimension measured by the unit.
+func (u Unit) Dimension() Dimension {
+	return unitInfos[u].dimension
+}
+
+// String returns the symbol of the unit.
+func (u Unit) String() string {
+	if _, ok := unitInfos[u]; !ok {
+		return "Unit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return u.Symbol()
+}
+
+// Kind is what a quantity measures when the unit alone is not enough to pick
+// how it is rendered. Body weight is the only mass rendered in stones and
+// body height the only length rendered in feet and inches.
+type Kind int
+
+// Kind constants.
+const (
+	// Generic is any quantity without a specific rendering.
+	Generic Kind = iota
+	// BodyWeight is the weight of the user.
+	BodyWeight
+	// BodyHeight is the height of the user.
+	BodyHeight
+)
+
+// Quantity is a value along with the unit it is expressed in and what it
+// measures.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+	Kind  Kind
+}
+
+// New creates a new generic Quantity.
+func New(value float64, unit Unit) Quantity {
+	return Quantity{Value: value, Unit: unit}
+}
+
+// WithKind returns the quantity measuring the kind provided.
+func (q Quantity) WithKind(k Kind) Quantity {
+	q.Kind = k
+	return q
+}
+
+// Convert returns the quantity expressed in the unit provided. An error is
+// returned if the units measure different dimensions.
+func (q Quantity) Convert(to Unit) (Quantity, error) {
+	from, ok := unitInfos[q.Unit]
+	if !ok {
+		return q, fmt.Errorf("unknown unit %s", q.Unit)
+	}
+	target, ok := unitInfos[to]
+	if !ok {
+		return q, fmt.Errorf("unknown unit %s", to)
+	}
+	if from.dimension != target.dimension {
+		return q, fmt.Errorf("cannot convert %s to %s", q.Unit, to)
+	}
+	if q.Unit == to {
+		return q, nil
+	}
+
+	base := q.Value*from.factor + from.offset
+	return Quantity{Value: (base - target.offset) / target.factor, Unit: to, Kind: q.Kind}, nil
+}
+
+// In returns the quantity converted to the preferred unit of the system for
+// its kind. If the system has no preference for the unit the quantity is
+// returned unchanged.
+func (q Quantity) In(s System) Quantity {
+	to := s.Preferred(q.Unit, q.Kind)
+	c, err := q.Convert(to)
+	if err != nil {
+		return q
+	}
+	return c
+}
+
+// Round returns the quantity with the value rounded to the decimal places
+// provided. Halves are rounded away from zero.
+func (q Quantity) Round(places int) Quantity {
+	q.Value = round(q.Value, places)
+	return q
+}
+
+// String renders the quantity using the default precision of the unit.
+func (q Quantity) String() string {
+	return q.Format(DefaultPrecision(q.Unit))
+}
+
+// Format renders the quantity with the decimal places provided. Stones are
+// rendered as stones and pounds and a body height in feet as feet and inches
+// with the places applied to the smaller unit, i.e. 11 st 4 lb and 5 ft 9 in.
+func (q Quantity) Format(places int) string {
+	switch {
+	case q.Unit == Stone:
+		return compound(q.Value, 14, places, "st", "lb")
+	case q.Unit == Foot && q.Kind == BodyHeight:
+		return compound(q.Value, 12, places, "ft", "in")
+	}
+
+	v := strconv.FormatFloat(round(q.Value, places), 'f', places, 64)
+	if q.Unit.Symbol() == "" {
+		return v
+	}
+	if q.Unit == Percent {
+		return v + q.Unit.Symbol()
+	}
+	return v + " " + q.Unit.Symbol()
+}
+
+// compound renders a value as a whole number of the major unit plus the
+// remainder in the minor unit. Rounding that produces a full major unit is
+// carried over so 5 ft 12 in is never rendered.
+func compound(value float64, minorPerMajor float64, places int, major string, minor string) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	total := round(value*minorPerMajor, places)
+	whole := math.Floor(total / minorPerMajor)
+	rest := round(total-whole*minorPerMajor, places)
+	if rest >= minorPerMajor {
+		whole++
+		rest = 0
+	}
+
+	return fmt.Sprintf("%s%d %s %s %s", sign, int64(whole), major, strconv.FormatFloat(rest, 'f', places, 64), minor)
+}
+
+// round rounds the value to the decimal places provided.
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+// DefaultPrecision returns the number of decimal places a unit is rendered
+// with by default.
+func DefaultPrecision(u Unit) int {
+	switch u {
+	case Kilogram, Pound, Celsius, Fahrenheit, Percent, Kilometer, Mile, MeterPerSecond, MilliliterPerMinutePerKilogram:
+		return 1
+	case Meter:
+		return 2
+	}
+	return 0
+}
+
+// System is a system of units used to render quantities.
+type System int
+
+// System constants.
+const (
+	// Metric renders everything in the metric units returned by the API.
+	Metric System = iota
+	// Imperial renders mass in pounds, length in feet, distance in miles
+	// and temperature in fahrenheit.
+	Imperial
+	// UK renders body weight in stones, height and elevation in feet and
+	// distance in miles while keeping other masses in kilograms and
+	// temperature in celsius.
+	UK
+)
+
+// String returns the name of the system.
+func (s System) String() string {
+	switch s {
+	case Metric:
+		return "Metric"
+	case Imperial:
+		return "Imperial"
+	case UK:
+		return "UK"
+	}
+	return "System(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Preferred returns the unit of the system that a quantity of the kind in the
+// unit provided should be rendered in. Metric units are mapped so that
+// Kilometer becomes Mile and Meter becomes Foot while units without an
+// alternative are returned as is. Stones are only preferred for body weight.
+func (s System) Preferred(u Unit, k Kind) Unit {
+	switch s {
+	case Imperial:
+		switch u {
+		case Kilogram, Stone:
+			return Pound
+		case Meter, Centimeter:
+			return Foot
+		case Kilometer:
+			return Mile
+		case Celsius:
+			return Fahrenheit
+		}
+	case UK:
+		switch u {
+		case Kilogram, Pound, Stone:
+			if k == BodyWeight {
+				return Stone
+			}
+			return Kilogram
+		case Meter, Centimeter:
+			return Foot
+		case Kilometer:
+			return Mile
+		case Fahrenheit:
+			return Celsius
+		}
+	default:
+		switch u {
+		case Pound, Stone:
+			return Kilogram
+		case Foot, Inch, Yard:
+			return Meter
+		case Mile:
+			return Kilometer
+		case Fahrenheit:
+			return Celsius
+		}
+	}
+	return u
+}
+
+// measTypeUnits maps each meastype to the unit its parsed value is in.
+var measTypeUnits = map[meastype.MeasType]Unit{
+	meastype.Weight:                         Kilogram,
+	meastype.Height:                         Meter,
+	meastype.FatFreeMassKg:                  Kilogram,
+	meastype.FatRatio:                       Percent,
+	meastype.FatMassWeightKg:                Kilogram,
+	meastype.DiastolicBloodPressureMMHG:     MillimeterOfMercury,
+	meastype.SystolicBloodPressureMMHG:      MillimeterOfMercury,
+	meastype.HeartPulseBPM:                  BeatsPerMinute,
+	meastype.Temperature:                    Celsius,
+	meastype.SP02Percent:                    Percent,
+	meastype.BodyTemperature:                Celsius,
+	meastype.SkinTemperature:                Celsius,
+	meastype.MuscleMass:                     Kilogram,
+	meastype.Hydration:                      Kilogram,
+	meastype.BoneMass:                       Kilogram,
+	meastype.PulseWaveVelocity:              MeterPerSecond,
+	meastype.VO2Max:                         MilliliterPerMinutePerKilogram,
+	meastype.QRSIntervalDuration:            Millisecond,
+	meastype.PRIntervalDuration:             Millisecond,
+	meastype.QTIntervalDuration:             Millisecond,
+	meastype.CorrectedQTIntervalDuration:    Millisecond,
+	meastype.VascularAge:                    Year,
+	meastype.ExtracellularWater:             Kilogram,
+	meastype.IntracellularWater:             Kilogram,
+	meastype.FatFreeMassSegments:            Kilogram,
+	meastype.FatMassSegments:                Kilogram,
+	meastype.MuscleMassSegments:             Kilogram,
+	meastype.BasalMetabolicRate:             Kilocalorie,
+	meastype.MetabolicAge:                   Year,
+	meastype.ElectrochemicalSkinConductance: Microsiemens,
+}
+
+// ForMeasType returns the unit the parsed value of the meastype is in. Types
+// without a unit return None.
+func ForMeasType(t meastype.MeasType) Unit {
+	return measTypeUnits[t]
+}
+
+// KindOfMeasType returns the kind of quantity measured by the meastype.
+func KindOfMeasType(t meastype.MeasType) Kind {
+	switch t {
+	case meastype.Weight:
+		return BodyWeight
+	case meastype.Height:
+		return BodyHeight
+	}
+	return Generic
+}
@@ -0,0 +1,91 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jrmycanady/nokiahealth/enum/meastype"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		from Quantity
+		to   Unit
+		want float64
+	}{
+		{New(100, Kilogram), Pound, 220.46226218},
+		{New(14, Pound), Stone, 1},
+		{New(1.8, Meter), Foot, 5.90551181},
+		{New(10, Kilometer), Mile, 6.21371192},
+		{New(37, Celsius), Fahrenheit, 98.6},
+		{New(212, Fahrenheit), Celsius, 100},
+		{New(1500, Millisecond), Second, 1.5},
+	}
+
+	for _, test := range tests {
+		got, err := test.from.Convert(test.to)
+		if err != nil {
+			t.Fatalf("failed to convert %v to %s: %s", test.from, test.to, err)
+		}
+		if math.Abs(got.Value-test.want) > 1e-6 || got.Unit != test.to {
+			t.Errorf("converting %v to %s expected %f got %v", test.from, test.to, test.want, got)
+		}
+	}
+
+	if _, err := New(1, Kilogram).Convert(Meter); err == nil {
+		t.Errorf("expected an error converting mass to length")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		q    Quantity
+		s    System
+		want string
+	}{
+		{New(72.46, Kilogram).WithKind(BodyWeight), Metric, "72.5 kg"},
+		{New(72.46, Kilogram).WithKind(BodyWeight), Imperial, "159.7 lb"},
+		{New(72.46, Kilogram).WithKind(BodyWeight), UK, "11 st 6 lb"},
+		{New(1.75, Meter).WithKind(BodyHeight), Metric, "1.75 m"},
+		{New(1.75, Meter).WithKind(BodyHeight), Imperial, "5 ft 9 in"},
+		{New(1.8288, Meter).WithKind(BodyHeight), UK, "6 ft 0 in"},
+		// Only body weight is rendered in stones and body height in feet
+		// and inches.
+		{New(2.7, Kilogram), UK, "2.7 kg"},
+		{New(2.7, Kilogram), Imperial, "6.0 lb"},
+		{New(350, Meter), Imperial, "1148 ft"},
+		{New(350, Meter), UK, "1148 ft"},
+		{New(12.3, Kilometer), Imperial, "7.6 mi"},
+		{New(36.6, Celsius), Imperial, "97.9 °F"},
+		{New(36.6, Celsius), UK, "36.6 °C"},
+		{New(21.34, Percent), UK, "21.3%"},
+		{New(120, MillimeterOfMercury), Imperial, "120 mmHg"},
+	}
+
+	for _, test := range tests {
+		got := test.q.In(test.s).String()
+		if got != test.want {
+			t.Errorf("rendering %v in %s expected %q got %q", test.q, test.s, test.want, got)
+		}
+	}
+}
+
+func TestCompoundCarry(t *testing.T) {
+	// 13.6 pounds rounds up to a full stone.
+	q := New(unitInfos[Stone].factor*2+unitInfos[Pound].factor*13.6, Kilogram).WithKind(BodyWeight).In(UK)
+	if got := q.Format(0); got != "3 st 0 lb" {
+		t.Errorf("expected carry to the next stone got %q", got)
+	}
+}
+
+func TestKindOfMeasType(t *testing.T) {
+	if k := KindOfMeasType(meastype.Weight); k != BodyWeight {
+		t.Errorf("expected weight to be a body weight got %d", k)
+	}
+	if k := KindOfMeasType(meastype.Height); k != BodyHeight {
+		t.Errorf("expected height to be a body height got %d", k)
+	}
+	if k := KindOfMeasType(meastype.BoneMass); k != Generic {
+		t.Errorf("expected bone mass to be generic got %d", k)
+	}
+}